@@ -0,0 +1,29 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"product/configs"
+	"product/controllers"
+	"product/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Init serves the HTTP endpoints (health checks, metrics and webhooks) next to the gRPC server
+func Init() {
+	middleware.PrometheusInit()
+	r := gin.Default()
+
+	r.GET("/health", controllers.NewHealthController().HealthCheck)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	r.POST("/webhooks/stripe", controllers.NewWebhookController().StripeWebhook)
+
+	addr := fmt.Sprintf("%s:%s", configs.API_LISTEN_HOST, configs.PORT)
+	log.Printf("HTTP server listening at %v", addr)
+	if err := r.Run(addr); err != nil {
+		log.Fatalf("failed to serve http: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role is what a user is allowed to do on the platform
+type Role string
+
+const (
+	RoleBuyer    Role = "buyer"
+	RoleMerchant Role = "merchant"
+	RoleAdmin    Role = "admin"
+)
+
+// Roles lists every role a token can grant
+var Roles = []Role{
+	RoleBuyer,
+	RoleMerchant,
+	RoleAdmin,
+}
+
+// Identity is the authenticated caller of a request.
+// Merchants act under their user ID, it is the MerchantId of their products.
+type Identity struct {
+	UserId uint64
+	Email  string
+	Roles  []Role
+}
+
+func (i *Identity) HasRole(role Role) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+type identityKey struct{}
+
+// NewContext returns a context carrying the identity
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity the request was authenticated as, if any
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// User returns the authenticated caller, requests without a token are rejected.
+// User IDs and emails in request messages are not trusted, this identity is used instead.
+func User(ctx context.Context) (*Identity, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "request is not authenticated")
+	}
+	return identity, nil
+}
+
+// MerchantId returns the ID of the authenticated merchant making the request.
+// Merchant IDs in request messages are not trusted, mutations are checked against this one.
+func MerchantId(ctx context.Context) (uint64, error) {
+	identity, err := User(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !identity.HasRole(RoleMerchant) {
+		return 0, status.Error(codes.PermissionDenied, "request is not authenticated as a merchant")
+	}
+	return identity.UserId, nil
+}
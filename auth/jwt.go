@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	// clockSkew is how far the clocks of the issuer and this service may drift apart
+	clockSkew = time.Minute
+	// jwksRefreshInterval limits how often keys are fetched again for tokens signed by an unknown key
+	jwksRefreshInterval = time.Minute
+)
+
+var signatureAlgorithms = []jose.SignatureAlgorithm{jose.RS256, jose.ES256}
+
+// claims are the claims of the access tokens issued to users
+type claims struct {
+	jwt.Claims
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+// Verifier checks access tokens against the keys of a JWKS.
+// Keys are loaded from a local file, or from a URL in which case they are fetched again when a token
+// is signed by a key that is not known yet, so the issuer can rotate its keys.
+type Verifier struct {
+	source   string
+	issuer   string
+	audience string
+
+	mu          sync.RWMutex
+	keys        jose.JSONWebKeySet
+	refreshedAt time.Time
+}
+
+// NewVerifier loads the JWKS from the file or http(s) URL in source.
+// Issuer and audience are checked when they are not empty.
+func NewVerifier(source, issuer, audience string) (*Verifier, error) {
+	v := &Verifier{
+		source:   source,
+		issuer:   issuer,
+		audience: audience,
+	}
+	if err := v.refresh(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Verify checks the signature and validity of the token and returns the identity it was issued for
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	parsed, err := jwt.ParseSigned(token, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	if len(parsed.Headers) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+
+	key, err := v.key(parsed.Headers[0])
+	if err != nil {
+		return nil, err
+	}
+	var tokenClaims claims
+	if err := parsed.Claims(key.Key, &tokenClaims); err != nil {
+		return nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	expected := jwt.Expected{Issuer: v.issuer, Time: time.Now()}
+	if v.audience != "" {
+		expected.AnyAudience = jwt.Audience{v.audience}
+	}
+	if err := tokenClaims.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+	if tokenClaims.Expiry == nil {
+		return nil, errors.New("token has no expiry")
+	}
+
+	userId, err := strconv.ParseUint(tokenClaims.Subject, 10, 64)
+	if err != nil || userId == 0 {
+		return nil, fmt.Errorf("invalid token subject %q", tokenClaims.Subject)
+	}
+	identity := &Identity{
+		UserId: userId,
+		Email:  tokenClaims.Email,
+	}
+	// Roles this service does not know about are ignored
+	for _, role := range tokenClaims.Roles {
+		if slices.Contains(Roles, Role(role)) {
+			identity.Roles = append(identity.Roles, Role(role))
+		}
+	}
+	return identity, nil
+}
+
+// key finds the public key the token was signed with, the algorithm must be the one of the key
+func (v *Verifier) key(header jose.Header) (*jose.JSONWebKey, error) {
+	key := v.lookup(header.KeyID)
+	if key == nil && v.isURL() {
+		v.mu.RLock()
+		stale := time.Since(v.refreshedAt) > jwksRefreshInterval
+		v.mu.RUnlock()
+		if stale {
+			if err := v.refresh(); err != nil {
+				return nil, err
+			}
+			key = v.lookup(header.KeyID)
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", header.KeyID)
+	}
+	if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+		return nil, fmt.Errorf("key %q does not sign with %s", header.KeyID, header.Algorithm)
+	}
+	return key, nil
+}
+
+// lookup finds the key by ID, a token without key ID can only use the key of a single key set
+func (v *Verifier) lookup(kid string) *jose.JSONWebKey {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if kid == "" {
+		if len(v.keys.Keys) == 1 {
+			return &v.keys.Keys[0]
+		}
+		return nil
+	}
+	keys := v.keys.Key(kid)
+	if len(keys) == 0 {
+		return nil
+	}
+	return &keys[0]
+}
+
+func (v *Verifier) isURL() bool {
+	return strings.HasPrefix(v.source, "https://") || strings.HasPrefix(v.source, "http://")
+}
+
+// refresh loads the key set again, only public keys are kept
+func (v *Verifier) refresh() error {
+	data, err := v.read()
+	if err != nil {
+		return fmt.Errorf("failed to load jwks: %w", err)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	publicKeys := make([]jose.JSONWebKey, 0, len(keys.Keys))
+	for _, key := range keys.Keys {
+		if key.Valid() && key.IsPublic() && key.Use != "enc" {
+			publicKeys = append(publicKeys, key)
+		}
+	}
+	if len(publicKeys) == 0 {
+		return errors.New("jwks has no public signing keys")
+	}
+
+	v.mu.Lock()
+	v.keys = jose.JSONWebKeySet{Keys: publicKeys}
+	v.refreshedAt = time.Now()
+	v.mu.Unlock()
+	return nil
+}
+
+func (v *Verifier) read() ([]byte, error) {
+	if !v.isURL() {
+		return os.ReadFile(v.source)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(v.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
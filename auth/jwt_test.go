@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	testIssuer   = "https://auth.example.com"
+	testAudience = "product"
+)
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// newTestVerifier trusts the public part of key under the key ID "k1", read from a JWKS file
+func newTestVerifier(t *testing.T, key *ecdsa.PrivateKey) *Verifier {
+	t.Helper()
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &key.PublicKey, KeyID: "k1", Algorithm: string(jose.ES256), Use: "sig"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewVerifier(path, testIssuer, testAudience)
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	return verifier
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, kid string, tokenClaims claims) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", kid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(signer).Claims(tokenClaims).Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// validClaims are the claims of a merchant token that is valid for another hour
+func validClaims() claims {
+	now := time.Now()
+	return claims{
+		Claims: jwt.Claims{
+			Subject:  "42",
+			Issuer:   testIssuer,
+			Audience: jwt.Audience{testAudience},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Email: "merchant@example.com",
+		Roles: []string{"merchant", "superuser"},
+	}
+}
+
+func TestVerifyAcceptsValidToken(t *testing.T) {
+	key := newKey(t)
+	verifier := newTestVerifier(t, key)
+
+	identity, err := verifier.Verify(sign(t, key, "k1", validClaims()))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if identity.UserId != 42 || identity.Email != "merchant@example.com" {
+		t.Errorf("identity %+v, want user 42 merchant@example.com", identity)
+	}
+	// Roles the service does not know are dropped
+	if !slices.Equal(identity.Roles, []Role{RoleMerchant}) {
+		t.Errorf("roles %v, want [merchant]", identity.Roles)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	key := newKey(t)
+	verifier := newTestVerifier(t, key)
+
+	for _, tc := range []struct {
+		name  string
+		token func() string
+	}{
+		{"expired", func() string {
+			c := validClaims()
+			c.Expiry = jwt.NewNumericDate(time.Now().Add(-clockSkew - time.Minute))
+			return sign(t, key, "k1", c)
+		}},
+		{"without expiry", func() string {
+			c := validClaims()
+			c.Expiry = nil
+			return sign(t, key, "k1", c)
+		}},
+		{"other issuer", func() string {
+			c := validClaims()
+			c.Issuer = "https://evil.example.com"
+			return sign(t, key, "k1", c)
+		}},
+		{"other audience", func() string {
+			c := validClaims()
+			c.Audience = jwt.Audience{"billing"}
+			return sign(t, key, "k1", c)
+		}},
+		{"subject is not a user ID", func() string {
+			c := validClaims()
+			c.Subject = "merchant@example.com"
+			return sign(t, key, "k1", c)
+		}},
+		{"signed by another key", func() string {
+			return sign(t, newKey(t), "k1", validClaims())
+		}},
+		{"unknown key ID", func() string {
+			return sign(t, key, "k2", validClaims())
+		}},
+		{"symmetric algorithm", func() string {
+			signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("0123456789abcdef0123456789abcdef")}, (&jose.SignerOptions{}).WithHeader("kid", "k1"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := jwt.Signed(signer).Claims(validClaims()).Serialize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}},
+		{"not a token", func() string { return "not.a.token" }},
+	} {
+		if identity, err := verifier.Verify(tc.token()); err == nil {
+			t.Errorf("%s: got identity %+v, want an error", tc.name, identity)
+		}
+	}
+}
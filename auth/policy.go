@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	"product/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Public lets anyone call a method, including requests without a token.
+// It is only used in policies, tokens can not grant it.
+const Public Role = "public"
+
+// Policy lists the roles that may call each method, keyed on the full gRPC method name.
+// Having any one of the roles is enough, methods that are not listed can not be called at all.
+type Policy map[string][]Role
+
+// LoadPolicy reads a policy from a JSON file mapping full method names to roles,
+// e.g. {"/product.ProductService/CreateProduct": ["merchant"]}.
+// Methods in the file replace their rule in the defaults, other methods keep the default one.
+func LoadPolicy(path string, defaults Policy) (Policy, error) {
+	policy := make(Policy, len(defaults))
+	for method, roles := range defaults {
+		policy[method] = roles
+	}
+	if path == "" {
+		return policy, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	var overrides Policy
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	for method, roles := range overrides {
+		for _, role := range roles {
+			if role != Public && !slices.Contains(Roles, role) {
+				return nil, fmt.Errorf("unknown role %q for %s", role, method)
+			}
+		}
+		policy[method] = roles
+	}
+	return policy, nil
+}
+
+// Check lists the methods of the services that have no rule, they would be denied to everyone
+func (p Policy) Check(services ...grpc.ServiceDesc) []string {
+	var missing []string
+	for _, service := range services {
+		for _, method := range service.Methods {
+			if _, ok := p["/"+service.ServiceName+"/"+method.MethodName]; !ok {
+				missing = append(missing, "/"+service.ServiceName+"/"+method.MethodName)
+			}
+		}
+		for _, stream := range service.Streams {
+			if _, ok := p["/"+service.ServiceName+"/"+stream.StreamName]; !ok {
+				missing = append(missing, "/"+service.ServiceName+"/"+stream.StreamName)
+			}
+		}
+	}
+	return missing
+}
+
+// authorize checks the caller in the context may call the method
+func (p Policy) authorize(ctx context.Context, method string) error {
+	roles, ok := p[method]
+	if !ok {
+		return p.deny(method, nil, "no_policy", codes.PermissionDenied)
+	}
+	if slices.Contains(roles, Public) {
+		return nil
+	}
+
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return p.deny(method, nil, "unauthenticated", codes.Unauthenticated)
+	}
+	for _, role := range roles {
+		if identity.HasRole(role) {
+			return nil
+		}
+	}
+	return p.deny(method, identity, "missing_role", codes.PermissionDenied)
+}
+
+func (p Policy) deny(method string, identity *Identity, reason string, code codes.Code) error {
+	middleware.AuthorizationDenials.WithLabelValues(method, reason).Inc()
+	if identity != nil {
+		log.Printf("denied %s to user %d with roles %v: %s", method, identity.UserId, identity.Roles, reason)
+	} else {
+		log.Printf("denied %s to anonymous caller: %s", method, reason)
+	}
+	if code == codes.Unauthenticated {
+		return status.Error(code, "request is not authenticated")
+	}
+	return status.Errorf(code, "not allowed to call %s", method)
+}
+
+// UnaryPolicyInterceptor enforces the policy, it must run after UnaryServerInterceptor authenticated the caller
+func UnaryPolicyInterceptor(policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := policy.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamPolicyInterceptor is UnaryPolicyInterceptor for streaming calls
+func StreamPolicyInterceptor(policy Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := policy.authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
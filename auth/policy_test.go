@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryPolicyInterceptor(t *testing.T) {
+	policy := Policy{
+		"/test.Service/List":   {Public},
+		"/test.Service/Create": {RoleMerchant, RoleAdmin},
+	}
+	interceptor := UnaryPolicyInterceptor(policy)
+
+	buyer := &Identity{UserId: 5, Roles: []Role{RoleBuyer}}
+	merchant := &Identity{UserId: 9, Roles: []Role{RoleMerchant}}
+
+	for _, tc := range []struct {
+		name     string
+		method   string
+		identity *Identity
+		code     codes.Code
+	}{
+		{"public method without token", "/test.Service/List", nil, codes.OK},
+		{"public method with token", "/test.Service/List", buyer, codes.OK},
+		{"without token", "/test.Service/Create", nil, codes.Unauthenticated},
+		{"missing role", "/test.Service/Create", buyer, codes.PermissionDenied},
+		{"one of the roles", "/test.Service/Create", merchant, codes.OK},
+		{"method without rule", "/test.Service/Delete", merchant, codes.PermissionDenied},
+	} {
+		ctx := context.Background()
+		if tc.identity != nil {
+			ctx = NewContext(ctx, tc.identity)
+		}
+		called := false
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		}
+
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+		if code := status.Code(err); code != tc.code {
+			t.Errorf("%s: got %s, want %s", tc.name, code, tc.code)
+		}
+		if called != (tc.code == codes.OK) {
+			t.Errorf("%s: handler called %t", tc.name, called)
+		}
+	}
+}
+
+func TestLoadPolicyOverridesDefaults(t *testing.T) {
+	defaults := Policy{
+		"/test.Service/List":   {Public},
+		"/test.Service/Create": {RoleMerchant},
+	}
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"/test.Service/Create": ["admin"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := LoadPolicy(path, defaults)
+	if err != nil {
+		t.Fatalf("LoadPolicy: %v", err)
+	}
+	if roles := policy["/test.Service/Create"]; len(roles) != 1 || roles[0] != RoleAdmin {
+		t.Errorf("Create is allowed to %v, want [admin]", roles)
+	}
+	if roles := policy["/test.Service/List"]; len(roles) != 1 || roles[0] != Public {
+		t.Errorf("List is allowed to %v, want the default [public]", roles)
+	}
+	if roles := defaults["/test.Service/Create"]; roles[0] != RoleMerchant {
+		t.Error("LoadPolicy changed the defaults")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"/test.Service/Create": ["owner"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPolicy(path, defaults); err == nil {
+		t.Error("LoadPolicy accepted an unknown role")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/stripe/stripe-go/v81"
@@ -28,6 +30,20 @@ var (
 	FRONTEND_URL                  string
 	GOOGLE_MAPS_API_KEY           string
 	ENVIRONMENT                   string
+	STRIPE_WEBHOOK_SECRET         string
+	ORDER_SWEEP_INTERVAL          time.Duration
+	ORDER_SETTLE_INTERVAL         time.Duration
+	PRODUCT_PUBLISH_INTERVAL      time.Duration
+	PRODUCT_PURGE_INTERVAL        time.Duration
+	PRODUCT_RETENTION             time.Duration
+	PLATFORM_COMMISSION_BPS       uint32
+	DEFAULT_CURRENCY              string
+	COUNTRY_CURRENCIES            map[string]string
+	PRICE_FACET_BUCKETS           []float64
+	JWKS_SOURCE                   string
+	JWT_ISSUER                    string
+	JWT_AUDIENCE                  string
+	RBAC_POLICY_FILE              string
 )
 
 func InitEnv() {
@@ -74,6 +90,78 @@ func InitEnv() {
 
 	// Init Stripe
 	stripe.Key = getEnv("STRIPE_SECRET_KEY", "some-secret-key")
+	STRIPE_WEBHOOK_SECRET = getEnv("STRIPE_WEBHOOK_SECRET", "some-webhook-secret")
+
+	// ISO 4217 currency for prices that do not specify one
+	DEFAULT_CURRENCY = strings.ToUpper(getEnv("DEFAULT_CURRENCY", "SGD"))
+
+	// currency buyers pay in per country, as comma separated COUNTRY=CURRENCY pairs
+	COUNTRY_CURRENCIES = make(map[string]string)
+	for _, pair := range strings.Split(getEnv("COUNTRY_CURRENCIES", "SG=SGD,MY=MYR,ID=IDR,TH=THB,PH=PHP,VN=VND,US=USD,GB=GBP,AU=AUD,JP=JPY"), ",") {
+		country, currency, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || len(currency) != 3 {
+			panic("Invalid value for COUNTRY_CURRENCIES")
+		}
+		COUNTRY_CURRENCIES[strings.ToUpper(country)] = strings.ToUpper(currency)
+	}
+
+	// upper bounds of the price facet buckets in major units of the listing currency, ascending
+	PRICE_FACET_BUCKETS = nil
+	for _, bound := range strings.Split(getEnv("PRICE_FACET_BUCKETS", "10,25,50,100,250,500"), ",") {
+		value, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+		if err != nil || value <= 0 || (len(PRICE_FACET_BUCKETS) > 0 && value <= PRICE_FACET_BUCKETS[len(PRICE_FACET_BUCKETS)-1]) {
+			panic("Invalid value for PRICE_FACET_BUCKETS")
+		}
+		PRICE_FACET_BUCKETS = append(PRICE_FACET_BUCKETS, value)
+	}
+
+	// default platform commission on each sale, in basis points (1000 = 10%)
+	platformCommissionBps, err := strconv.ParseUint(getEnv("PLATFORM_COMMISSION_BPS", "1000"), 10, 32)
+	if err != nil || platformCommissionBps > 10000 {
+		panic("Invalid value for PLATFORM_COMMISSION_BPS")
+	}
+	PLATFORM_COMMISSION_BPS = uint32(platformCommissionBps)
+
+	// how often abandoned checkouts are released back into inventory
+	orderSweepInterval, err := time.ParseDuration(getEnv("ORDER_SWEEP_INTERVAL", "5m"))
+	if err != nil {
+		panic("Invalid value for ORDER_SWEEP_INTERVAL")
+	}
+	ORDER_SWEEP_INTERVAL = orderSweepInterval
+
+	// how often paid orders whose merchants were not paid yet are settled again
+	orderSettleInterval, err := time.ParseDuration(getEnv("ORDER_SETTLE_INTERVAL", "5m"))
+	if err != nil {
+		panic("Invalid value for ORDER_SETTLE_INTERVAL")
+	}
+	ORDER_SETTLE_INTERVAL = orderSettleInterval
+
+	// how often scheduled products that are due get published
+	productPublishInterval, err := time.ParseDuration(getEnv("PRODUCT_PUBLISH_INTERVAL", "1m"))
+	if err != nil {
+		panic("Invalid value for PRODUCT_PUBLISH_INTERVAL")
+	}
+	PRODUCT_PUBLISH_INTERVAL = productPublishInterval
+
+	// how often and how long after deletion deleted products are purged for good
+	productPurgeInterval, err := time.ParseDuration(getEnv("PRODUCT_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		panic("Invalid value for PRODUCT_PURGE_INTERVAL")
+	}
+	PRODUCT_PURGE_INTERVAL = productPurgeInterval
+	productRetention, err := time.ParseDuration(getEnv("PRODUCT_RETENTION", "720h"))
+	if err != nil || productRetention < 0 {
+		panic("Invalid value for PRODUCT_RETENTION")
+	}
+	PRODUCT_RETENTION = productRetention
+
+	// access tokens, the JWKS is a local file or an http(s) URL, issuer and audience are only checked when set
+	JWKS_SOURCE = getEnv("JWKS_SOURCE", "/app/secrets/jwks.json")
+	JWT_ISSUER = getEnv("JWT_ISSUER", "")
+	JWT_AUDIENCE = getEnv("JWT_AUDIENCE", "")
+
+	// JSON file with the roles allowed per RPC, replacing the built-in rule of each method it lists
+	RBAC_POLICY_FILE = getEnv("RBAC_POLICY_FILE", "")
 
 	// frontend (for stripe)
 	FRONTEND_URL = getEnv("FRONTEND_URL", "http://localhost:3000")
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log"
 
+	"product/auth"
 	pb "product/proto"
 	"product/services"
 )
@@ -23,7 +24,12 @@ func NewProductController(cartService *services.CartService) *ProductController
 }
 
 func (p *ProductController) GetProduct(ctx context.Context, message *pb.GetProductRequest) (*pb.Product, error) {
-	product, err := services.NewProductService().GetProduct(message.GetId())
+	// Anyone may call it, merchants signed in also get their unpublished products
+	var merchantId uint64
+	if identity, ok := auth.FromContext(ctx); ok && identity.HasRole(auth.RoleMerchant) {
+		merchantId = identity.UserId
+	}
+	product, err := services.NewProductService().GetProduct(message.GetId(), merchantId)
 	if err != nil {
 		return nil, err
 	}
@@ -31,13 +37,23 @@ func (p *ProductController) GetProduct(ctx context.Context, message *pb.GetProdu
 }
 
 func (p *ProductController) CreateProduct(ctx context.Context, message *pb.CreateProductRequest) (*pb.Product, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
 	product, err := services.NewProductService().CreateProduct(&pb.Product{
-		Name:        message.GetName(),
-		Description: message.GetDescription(),
-		Price:       message.GetPrice(),
-		Inventory:   message.GetInventory(),
-		MerchantId:  message.GetMerchantId(),
-	})
+		Name:           message.GetName(),
+		Description:    message.GetDescription(),
+		Price:          message.GetPrice(),
+		PriceMinor:     message.GetPriceMinor(),
+		Currency:       message.GetCurrency(),
+		Prices:         message.GetPrices(),
+		Inventory:      message.GetInventory(),
+		MerchantId:     merchantId,
+		Specifications: message.GetSpecifications(),
+		Status:         message.GetStatus(),
+		PublishAt:      message.GetPublishAt(),
+	}, message.GetCategoryIds())
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +61,23 @@ func (p *ProductController) CreateProduct(ctx context.Context, message *pb.Creat
 }
 
 func (p *ProductController) UpdateProduct(ctx context.Context, message *pb.UpdateProductRequest) (*pb.Product, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
 	product, err := services.NewProductService().UpdateProduct(&pb.Product{
-		Id:          message.GetId(),
-		Name:        message.GetName(),
-		Description: message.GetDescription(),
-		Price:       message.GetPrice(),
-		Inventory:   message.GetInventory(),
-		MerchantId:  message.GetMerchantId(),
+		Id:             message.GetId(),
+		Name:           message.GetName(),
+		Description:    message.GetDescription(),
+		Price:          message.GetPrice(),
+		PriceMinor:     message.GetPriceMinor(),
+		Currency:       message.GetCurrency(),
+		Prices:         message.GetPrices(),
+		Inventory:      message.GetInventory(),
+		MerchantId:     merchantId,
+		Specifications: message.GetSpecifications(),
+		Status:         message.GetStatus(),
+		PublishAt:      message.GetPublishAt(),
 	})
 	if err != nil {
 		return nil, err
@@ -60,13 +86,30 @@ func (p *ProductController) UpdateProduct(ctx context.Context, message *pb.Updat
 }
 
 func (p *ProductController) DeleteProduct(ctx context.Context, message *pb.DeleteProductRequest) (*pb.Empty, error) {
-	err := services.NewProductService().DeleteProduct(message.GetId())
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = services.NewProductService().DeleteProduct(message.GetId(), merchantId)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.Empty{}, nil
 }
 
+func (p *ProductController) RestoreProduct(ctx context.Context, message *pb.RestoreProductRequest) (*pb.Product, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	message.MerchantId = merchantId
+	product, err := services.NewProductService().RestoreProduct(message)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
 func (p *ProductController) ListProducts(ctx context.Context, message *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 	products, err := services.NewProductService().ListProducts(message)
 	if err != nil {
@@ -75,6 +118,27 @@ func (p *ProductController) ListProducts(ctx context.Context, message *pb.ListPr
 	return products, nil
 }
 
+func (p *ProductController) ListMerchantProducts(ctx context.Context, message *pb.ListMerchantProductsRequest) (*pb.ListProductsResponse, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	message.MerchantId = merchantId
+	products, err := services.NewProductService().ListMerchantProducts(message)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (p *ProductController) SearchProducts(ctx context.Context, message *pb.SearchProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := services.NewProductService().SearchProducts(message)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
 // func (p *ProductController) UpdateProductImages(ctx context.Context, stream *pb.UpdateProductImagesRequest) (*pb.UpdateProductImagesResponse, error) {
 // 	resp, err := services.NewProductService().UpdateProductImages(stream)
 // 	if err != nil {
@@ -84,12 +148,23 @@ func (p *ProductController) ListProducts(ctx context.Context, message *pb.ListPr
 // }
 
 func (p *ProductController) UpdateProductImages(stream pb.ProductService_UpdateProductImagesServer) error {
+	merchantId, err := auth.MerchantId(stream.Context())
+	if err != nil {
+		return err
+	}
+
 	fileBuffers := make(map[string]*bytes.Buffer) // Stores file contents
-	var id uint64
+	var id, variantId uint64
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			log.Println("EOF received")
+
+			// Nothing is uploaded for products of other merchants
+			if err := services.NewProductService().CheckMerchantProduct(id, merchantId); err != nil {
+				return err
+			}
+
 			var uploadedURLs []string
 			for filename, buffer := range fileBuffers {
 
@@ -102,10 +177,15 @@ func (p *ProductController) UpdateProductImages(stream pb.ProductService_UpdateP
 				uploadedURLs = append(uploadedURLs, url)
 			}
 
-			_, err := services.NewProductService().UpdateProductImages(&pb.Product{
-				Id:     id,
-				Images: uploadedURLs,
-			})
+			var err error
+			if variantId != 0 {
+				err = services.NewVariantService().UpdateVariantImages(id, variantId, uploadedURLs)
+			} else {
+				_, err = services.NewProductService().UpdateProductImages(&pb.Product{
+					Id:     id,
+					Images: uploadedURLs,
+				})
+			}
 			if err != nil {
 				log.Println("error updating product: ", err)
 				return fmt.Errorf("error updating product: %v", err)
@@ -118,6 +198,7 @@ func (p *ProductController) UpdateProductImages(stream pb.ProductService_UpdateP
 		}
 
 		id = req.Id
+		variantId = req.VariantId
 		filename := req.Filename
 		if _, exists := fileBuffers[filename]; !exists {
 			fileBuffers[filename] = &bytes.Buffer{}
@@ -134,17 +215,322 @@ func (p *ProductController) UpdateProductImages(stream pb.ProductService_UpdateP
 }
 
 func (p *ProductController) ValidateProductInventory(ctx context.Context, message *pb.ValidateProductInventoryRequest) (*pb.ValidateProductInventoryResponse, error) {
-	valid, err := services.NewProductService().ValidateProductInventory(message.GetProductId(), message.GetQuantity())
+	valid, err := services.NewProductService().ValidateProductInventory(message.GetProductId(), message.GetVariantId(), message.GetQuantity())
 	if err != nil {
 		return nil, err
 	}
 	return &pb.ValidateProductInventoryResponse{Valid: valid}, nil
 }
 
+func (p *ProductController) GetStockHistory(ctx context.Context, req *pb.GetStockHistoryRequest) (*pb.GetStockHistoryResponse, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	resp, err := services.NewStockService().GetStockHistory(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *ProductController) SetProductOptions(ctx context.Context, req *pb.SetProductOptionsRequest) (*pb.Product, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	product, err := services.NewVariantService().SetProductOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (p *ProductController) CreateProductVariant(ctx context.Context, req *pb.CreateProductVariantRequest) (*pb.ProductVariant, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	variant, err := services.NewVariantService().CreateProductVariant(req)
+	if err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (p *ProductController) UpdateProductVariant(ctx context.Context, req *pb.UpdateProductVariantRequest) (*pb.ProductVariant, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	variant, err := services.NewVariantService().UpdateProductVariant(req)
+	if err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (p *ProductController) DeleteProductVariant(ctx context.Context, req *pb.DeleteProductVariantRequest) (*pb.Empty, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	err = services.NewVariantService().DeleteProductVariant(req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (p *ProductController) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.Category, error) {
+	category, err := services.NewCategoryService().CreateCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (p *ProductController) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.Category, error) {
+	category, err := services.NewCategoryService().UpdateCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (p *ProductController) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.Empty, error) {
+	err := services.NewCategoryService().DeleteCategory(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (p *ProductController) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.Category, error) {
+	category, err := services.NewCategoryService().GetCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (p *ProductController) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	resp, err := services.NewCategoryService().ListCategories(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *ProductController) SetProductCategories(ctx context.Context, req *pb.SetProductCategoriesRequest) (*pb.Product, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	product, err := services.NewCategoryService().SetProductCategories(req)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
 func (p *ProductController) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = user.UserId
+	req.UserEmail = user.Email
 	resp, err := services.NewOrderService(p.cartService).PlaceOrder(req)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
+
+func (p *ProductController) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := services.NewOrderService(p.cartService).GetOrderFor(req.GetId(), user)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (p *ProductController) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = user.UserId
+	orders, err := services.NewOrderService(p.cartService).ListOrders(req)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (p *ProductController) ListMerchantOrders(ctx context.Context, req *pb.ListMerchantOrdersRequest) (*pb.ListOrdersResponse, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	orders, err := services.NewOrderService(p.cartService).ListMerchantOrders(req)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (p *ProductController) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = user.UserId
+	order, err := services.NewOrderService(p.cartService).CancelOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (p *ProductController) RefundOrder(ctx context.Context, req *pb.RefundOrderRequest) (*pb.RefundOrderResponse, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := services.NewRefundService().RefundOrder(req, user.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *ProductController) OnboardMerchant(ctx context.Context, req *pb.OnboardMerchantRequest) (*pb.OnboardMerchantResponse, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	resp, err := services.NewPayoutService().OnboardMerchant(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *ProductController) SetMerchantCommission(ctx context.Context, req *pb.SetMerchantCommissionRequest) (*pb.Merchant, error) {
+	merchant, err := services.NewPayoutService().SetMerchantCommission(req)
+	if err != nil {
+		return nil, err
+	}
+	return merchant, nil
+}
+
+func (p *ProductController) GetOrderLedger(ctx context.Context, req *pb.GetOrderLedgerRequest) (*pb.GetOrderLedgerResponse, error) {
+	resp, err := services.NewPayoutService().GetOrderLedger(req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *ProductController) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.Shipment, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	shipment, err := services.NewShipmentService().CreateShipment(req)
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+func (p *ProductController) UpdateShipment(ctx context.Context, req *pb.UpdateShipmentRequest) (*pb.Shipment, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	shipment, err := services.NewShipmentService().UpdateShipment(req)
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+func (p *ProductController) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.Return, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = user.UserId
+	ret, err := services.NewReturnService().RequestReturn(req)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (p *ProductController) ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.Return, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	ret, err := services.NewReturnService().ApproveReturn(req)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (p *ProductController) RejectReturn(ctx context.Context, req *pb.RejectReturnRequest) (*pb.Return, error) {
+	merchantId, err := auth.MerchantId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.MerchantId = merchantId
+	ret, err := services.NewReturnService().RejectReturn(req)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (p *ProductController) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.Return, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := services.NewReturnService().GetReturn(req.GetId(), user)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (p *ProductController) ListOrderReturns(ctx context.Context, req *pb.ListOrderReturnsRequest) (*pb.ListOrderReturnsResponse, error) {
+	user, err := auth.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := services.NewReturnService().ListOrderReturns(req.GetOrderId(), user)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
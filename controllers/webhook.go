@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"product/configs"
+	"product/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stripe/stripe-go/v81/webhook"
+)
+
+// maxWebhookBodyBytes follows Stripe's recommended payload limit
+const maxWebhookBodyBytes = int64(65536)
+
+type WebhookController struct{}
+
+func NewWebhookController() *WebhookController {
+	return &WebhookController{}
+}
+
+func (w *WebhookController) StripeWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		log.Println("error reading webhook body: ", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unable to read body"})
+		return
+	}
+
+	event, err := webhook.ConstructEventWithOptions(payload, c.GetHeader("Stripe-Signature"), configs.STRIPE_WEBHOOK_SECRET,
+		webhook.ConstructEventOptions{IgnoreAPIVersionMismatch: true})
+	if err != nil {
+		log.Println("error verifying webhook signature: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	if err := services.NewPaymentService().HandleStripeEvent(&event); err != nil {
+		// Non-2xx makes Stripe retry the delivery later
+		log.Println("error handling stripe event: ", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "unable to process event"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -5,6 +5,7 @@ go 1.23.4
 require (
 	cloud.google.com/go/storage v1.51.0
 	github.com/gin-gonic/gin v1.10.0
+	github.com/go-jose/go-jose/v4 v4.0.5
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
@@ -4,6 +4,8 @@ import (
 	"log"
 	"net"
 
+	"product/auth"
+	"product/configs"
 	"product/controllers"
 	pb "product/proto"
 	"product/services"
@@ -21,7 +23,24 @@ func Init() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	verifier, err := auth.NewVerifier(configs.JWKS_SOURCE, configs.JWT_ISSUER, configs.JWT_AUDIENCE)
+	if err != nil {
+		log.Fatalf("failed to load access token keys: %v", err)
+	}
+
+	policy, err := auth.LoadPolicy(configs.RBAC_POLICY_FILE, defaultPolicy)
+	if err != nil {
+		log.Fatalf("failed to load access policy: %v", err)
+	}
+	for _, method := range policy.Check(pb.ProductService_ServiceDesc, grpc_health_v1.Health_ServiceDesc) {
+		log.Printf("no access policy for %s, it is denied to everyone", method)
+	}
+
+	// Callers are authenticated first, then checked against the policy
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(verifier), auth.UnaryPolicyInterceptor(policy)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(verifier), auth.StreamPolicyInterceptor(policy)),
+	)
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(s, healthServer)
 	healthServer.SetServingStatus("ProductService", grpc_health_v1.HealthCheckResponse_SERVING)
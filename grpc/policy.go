@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"product/auth"
+	pb "product/proto"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var (
+	public   = []auth.Role{auth.Public}
+	buyer    = []auth.Role{auth.RoleBuyer}
+	merchant = []auth.Role{auth.RoleMerchant}
+	admin    = []auth.Role{auth.RoleAdmin}
+	anyRole  = []auth.Role{auth.RoleBuyer, auth.RoleMerchant, auth.RoleAdmin}
+)
+
+// defaultPolicy is who may call each RPC, RBAC_POLICY_FILE can replace the rule of any method
+var defaultPolicy = auth.Policy{
+	grpc_health_v1.Health_Check_FullMethodName: public,
+	grpc_health_v1.Health_Watch_FullMethodName: public,
+
+	// catalog
+	pb.ProductService_ListProducts_FullMethodName:             public,
+	pb.ProductService_SearchProducts_FullMethodName:           public,
+	pb.ProductService_GetProduct_FullMethodName:               public,
+	pb.ProductService_ValidateProductInventory_FullMethodName: public,
+	pb.ProductService_GetCategory_FullMethodName:              public,
+	pb.ProductService_ListCategories_FullMethodName:           public,
+
+	// merchants managing their products
+	pb.ProductService_ListMerchantProducts_FullMethodName: merchant,
+	pb.ProductService_CreateProduct_FullMethodName:        merchant,
+	pb.ProductService_UpdateProduct_FullMethodName:        merchant,
+	pb.ProductService_DeleteProduct_FullMethodName:        merchant,
+	pb.ProductService_RestoreProduct_FullMethodName:       merchant,
+	pb.ProductService_UpdateProductImages_FullMethodName:  merchant,
+	pb.ProductService_SetProductOptions_FullMethodName:    merchant,
+	pb.ProductService_CreateProductVariant_FullMethodName: merchant,
+	pb.ProductService_UpdateProductVariant_FullMethodName: merchant,
+	pb.ProductService_DeleteProductVariant_FullMethodName: merchant,
+	pb.ProductService_SetProductCategories_FullMethodName: merchant,
+	pb.ProductService_GetStockHistory_FullMethodName:      merchant,
+
+	// category tree
+	pb.ProductService_CreateCategory_FullMethodName: admin,
+	pb.ProductService_UpdateCategory_FullMethodName: admin,
+	pb.ProductService_DeleteCategory_FullMethodName: admin,
+
+	// buyers
+	pb.ProductService_PlaceOrder_FullMethodName:    buyer,
+	pb.ProductService_ListOrders_FullMethodName:    buyer,
+	pb.ProductService_CancelOrder_FullMethodName:   buyer,
+	pb.ProductService_RequestReturn_FullMethodName: buyer,
+
+	// orders seen by both sides
+	pb.ProductService_GetOrder_FullMethodName:         anyRole,
+	pb.ProductService_GetReturn_FullMethodName:        anyRole,
+	pb.ProductService_ListOrderReturns_FullMethodName: anyRole,
+
+	// fulfilment
+	pb.ProductService_ListMerchantOrders_FullMethodName: merchant,
+	pb.ProductService_CreateShipment_FullMethodName:     merchant,
+	pb.ProductService_UpdateShipment_FullMethodName:     merchant,
+	pb.ProductService_ApproveReturn_FullMethodName:      merchant,
+	pb.ProductService_RejectReturn_FullMethodName:       merchant,
+	pb.ProductService_OnboardMerchant_FullMethodName:    merchant,
+
+	// platform
+	pb.ProductService_RefundOrder_FullMethodName:           admin,
+	pb.ProductService_GetOrderLedger_FullMethodName:        admin,
+	pb.ProductService_SetMerchantCommission_FullMethodName: admin,
+}
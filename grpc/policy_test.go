@@ -0,0 +1,14 @@
+package grpc
+
+import (
+	pb "product/proto"
+	"testing"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestDefaultPolicyCoversEveryMethod(t *testing.T) {
+	for _, method := range defaultPolicy.Check(pb.ProductService_ServiceDesc, grpc_health_v1.Health_ServiceDesc) {
+		t.Errorf("%s has no rule in the default policy", method)
+	}
+}
@@ -1,13 +1,20 @@
 package main
 
 import (
+	"product/api"
 	"product/configs"
 	"product/grpc"
+	"product/services"
 	"product/storage"
 )
 
 func main() {
-	configs.InitEnv()            // init env
-	storage.GetStorageInstance() // init db
+	configs.InitEnv()                                                                               // init env
+	storage.GetStorageInstance()                                                                    // init db
+	go api.Init()                                                                                   // http (webhooks)
+	go services.NewOrderSweeper(configs.ORDER_SWEEP_INTERVAL).Start()                               // release abandoned checkouts
+	go services.NewOrderSettler(configs.ORDER_SETTLE_INTERVAL).Start()                              // pay merchants of paid orders
+	go services.NewProductPublisher(configs.PRODUCT_PUBLISH_INTERVAL).Start()                       // publish scheduled products
+	go services.NewProductPurger(configs.PRODUCT_PURGE_INTERVAL, configs.PRODUCT_RETENTION).Start() // purge long deleted products
 	grpc.Init()
 }
@@ -23,11 +23,20 @@ var (
 		},
 		[]string{"path", "method", "status"},
 	)
+
+	AuthorizationDenials = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_authorization_denied_total",
+			Help: "Total number of gRPC calls denied by the access policy.",
+		},
+		[]string{"method", "reason"},
+	)
 )
 
 func PrometheusInit() {
 	prometheus.MustRegister(RequestCount)
 	prometheus.MustRegister(ErrorCount)
+	prometheus.MustRegister(AuthorizationDenials)
 }
 
 func TrackMetrics() gin.HandlerFunc {
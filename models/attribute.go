@@ -0,0 +1,28 @@
+package models
+
+// ProductSpecifications are the standard specifications of a product
+type ProductSpecifications struct {
+	Brand       string `json:"brand" gorm:"index"`
+	Material    string `json:"material"`
+	WeightGrams uint64 `json:"weight_grams"`
+	LengthMm    uint64 `json:"length_mm"`
+	WidthMm     uint64 `json:"width_mm"`
+	HeightMm    uint64 `json:"height_mm"`
+}
+
+type AttributeType string
+
+var (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+// ProductAttribute is a custom key/value specification of a product
+type ProductAttribute struct {
+	ProductId   uint64        `json:"product_id" gorm:"primaryKey"`
+	Key         string        `json:"key" gorm:"primaryKey;index:idx_product_attributes_key_value,priority:1"`
+	Type        AttributeType `json:"type" gorm:"default:string"`
+	Value       string        `json:"value" gorm:"index:idx_product_attributes_key_value,priority:2"` // Text form of the value, for filters and facets
+	NumberValue *float64      `json:"number_value,omitempty"`                                         // Only set for number attributes, for range filters
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Category is a node of the category tree, products can be in several categories
+type Category struct {
+	Id          uint64  `json:"id" gorm:"primaryKey"`
+	ParentId    *uint64 `json:"parent_id,omitempty" gorm:"index"` // nil for top level categories
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug" gorm:"uniqueIndex"`
+	Description string  `json:"description"`
+	// platform commission in basis points on sales in the category, nil for the default
+	CommissionBps *uint32   `json:"commission_bps,omitempty"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
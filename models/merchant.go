@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Merchant links a merchant to their Stripe Connect account for payouts
+type Merchant struct {
+	Id              uint64    `json:"id" gorm:"primaryKey;autoIncrement:false"` // same as Product.MerchantId
+	StripeAccountId string    `json:"stripe_account_id"`
+	CommissionBps   *uint32   `json:"commission_bps"` // platform commission in basis points, nil uses the default
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type LedgerEntryType string
+
+var (
+	LedgerEntryCharge                  LedgerEntryType = "charge"
+	LedgerEntryPlatformFee             LedgerEntryType = "platform_fee"
+	LedgerEntryMerchantPayable         LedgerEntryType = "merchant_payable"
+	LedgerEntryTransfer                LedgerEntryType = "transfer"
+	LedgerEntryRefund                  LedgerEntryType = "refund"
+	LedgerEntryPlatformFeeRefund       LedgerEntryType = "platform_fee_refund"
+	LedgerEntryTransferReversal        LedgerEntryType = "transfer_reversal"
+	LedgerEntryMerchantPayableReversal LedgerEntryType = "merchant_payable_reversal"
+)
+
+// LedgerEntry records money moving between the buyer, the platform and a merchant for an order.
+// Amounts are always positive and in cents, the type gives the direction.
+type LedgerEntry struct {
+	Id             uint64          `json:"id" gorm:"primaryKey"`
+	OrderId        uint64          `json:"order_id" gorm:"index"`
+	SubOrderId     uint64          `json:"sub_order_id"`
+	MerchantId     uint64          `json:"merchant_id" gorm:"index"`
+	Type           LedgerEntryType `json:"type"`
+	Amount         int64           `json:"amount"`
+	Currency       string          `json:"currency"`
+	StripeObjectId string          `json:"stripe_object_id"`
+	Key            *string         `json:"key,omitempty" gorm:"uniqueIndex"` // Entries with a key are recorded once, e.g. when an order is settled again
+	CreatedAt      time.Time       `json:"created_at" gorm:"autoCreateTime"`
+}
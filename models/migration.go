@@ -0,0 +1,11 @@
+package models
+
+import (
+	"time"
+)
+
+// Migration records a data migration that has already run
+type Migration struct {
+	Name      string    `json:"name" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
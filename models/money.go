@@ -0,0 +1,30 @@
+package models
+
+import (
+	"math"
+	"strings"
+)
+
+// zeroDecimalCurrencies are charged in whole units, see https://docs.stripe.com/currencies#zero-decimal
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true, "KRW": true, "MGA": true,
+	"PYG": true, "RWF": true, "UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// MinorUnitFactor is the number of minor units in one unit of the currency, e.g. 100 cents in a dollar
+func MinorUnitFactor(currency string) int64 {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return 1
+	}
+	return 100
+}
+
+// ToMinorUnits converts an amount from the deprecated float fields, rounding instead of truncating
+func ToMinorUnits(amount float32, currency string) int64 {
+	return int64(math.Round(float64(amount) * float64(MinorUnitFactor(currency))))
+}
+
+// FromMinorUnits converts minor units back into the deprecated float representation
+func FromMinorUnits(amount int64, currency string) float32 {
+	return float32(float64(amount) / float64(MinorUnitFactor(currency)))
+}
@@ -11,33 +11,129 @@ var (
 	OrderStatusProcessing OrderStatus = "processing"
 	OrderStatusCompleted  OrderStatus = "completed"
 	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusDelivered  OrderStatus = "delivered"
+
+	OrderStatusRefunded          OrderStatus = "refunded"
+	OrderStatusPartiallyRefunded OrderStatus = "partially_refunded"
 
 	PaymentStatusPending   PaymentStatus = "pending"
 	PaymentStatusCompleted PaymentStatus = "completed"
 	PaymentStatusCancelled PaymentStatus = "cancelled"
+	PaymentStatusFailed    PaymentStatus = "failed"
+	// the buyer paid with a delayed payment method and the money has not arrived yet
+	PaymentStatusProcessing PaymentStatus = "processing"
+
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
 )
 
-// Order represents a user's order
+// PaymentStatuses lists every value of the payment_status enum
+var PaymentStatuses = []PaymentStatus{
+	PaymentStatusPending,
+	PaymentStatusCompleted,
+	PaymentStatusCancelled,
+	PaymentStatusFailed,
+	PaymentStatusProcessing,
+	PaymentStatusRefunded,
+	PaymentStatusPartiallyRefunded,
+}
+
+// Order represents a user's order.
+// The buyer's order is split into one sub-order per merchant, sub-orders have a ParentId
+// and group the parent's order items that belong to their merchant.
 type Order struct {
 	Id                uint64        `json:"id" gorm:"primaryKey"`
-	UserId            uint64        `json:"user_id"`
-	Total             float32       `json:"total"`
+	ParentId          *uint64       `json:"parent_id,omitempty" gorm:"index"`
+	MerchantId        uint64        `json:"merchant_id,omitempty" gorm:"index"`
+	UserId            uint64        `json:"user_id" gorm:"uniqueIndex:idx_orders_user_idempotency_key,where:idempotency_key <> ''"`
+	TotalMinor        int64         `json:"total_minor"` // In minor units of Currency
+	Currency          string        `json:"currency" gorm:"type:char(3)"`
 	Status            OrderStatus   `json:"status" gorm:"gorm:type:order_status;default:processing"`
 	TransactionId     string        `json:"transaction_id"`
 	CheckoutSessionId string        `json:"checkout_session_id"`
 	PaymentStatus     PaymentStatus `json:"payment_status" gorm:"type:payment_status;default:pending"`
 	OrderItems        []OrderItem   `json:"order_items" gorm:"foreignKey:OrderId"`
+	Refunds           []Refund      `json:"refunds" gorm:"foreignKey:OrderId"`
+	SubOrders         []Order       `json:"sub_orders,omitempty" gorm:"foreignKey:ParentId"`
+	SubOrderItems     []OrderItem   `json:"sub_order_items,omitempty" gorm:"foreignKey:SubOrderId"`
+	Shipments         []Shipment    `json:"shipments,omitempty" gorm:"foreignKey:OrderId"` // Only on sub-orders
+	Reservations      []Reservation `json:"-" gorm:"foreignKey:OrderId"`                   // Stock held until the order is paid, only on the buyer's order
 	Address           string        `json:"address"`
+	CheckoutUrl       string        `json:"checkout_url"`
+	IdempotencyKey    string        `json:"idempotency_key" gorm:"uniqueIndex:idx_orders_user_idempotency_key"`
+	IdempotencyHash   string        `json:"-"`                    // Fingerprint of the request that used the key
+	SettledAt         *time.Time    `json:"settled_at,omitempty"` // When the merchants got their share, nil until the order is paid and settled
 	CreatedAt         time.Time     `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt         time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // OrderItem represents an item in an order
 type OrderItem struct {
-	OrderId   uint64    `json:"order_id" gorm:"primaryKey"`
-	ProductId uint64    `json:"product_id" gorm:"primaryKey"`
-	Quantity  uint64    `json:"quantity"`
-	Price     float32   `json:"price"` // Storing price at time of purchase
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	OrderId    uint64    `json:"order_id" gorm:"primaryKey"`
+	ProductId  uint64    `json:"product_id" gorm:"primaryKey"`
+	VariantId  uint64    `json:"variant_id" gorm:"primaryKey;autoIncrement:false;default:0"` // 0 for products without variants
+	Sku        string    `json:"sku"`                                                        // Variant SKU at time of purchase
+	SubOrderId *uint64   `json:"sub_order_id,omitempty" gorm:"index"`
+	Quantity   uint64    `json:"quantity"`
+	PriceMinor int64     `json:"price_minor"` // Storing price at time of purchase, in the order's currency
+	Product    *Product  `json:"product,omitempty" gorm:"foreignKey:ProductId"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	RefundedQuantity uint64 `json:"refunded_quantity" gorm:"default:0"`
+	CommissionBps    uint32 `json:"commission_bps" gorm:"default:0"` // Platform commission at time of purchase
+	ShippedQuantity  uint64 `json:"shipped_quantity" gorm:"default:0"`
+	ReturnedQuantity uint64 `json:"returned_quantity" gorm:"default:0"` // Held by open or approved returns
+}
+
+// LineKey identifies an order line, the same product can be bought in several variants
+type LineKey struct {
+	ProductId uint64
+	VariantId uint64
+}
+
+// Key returns the line the item is for
+func (i *OrderItem) Key() LineKey {
+	return LineKey{ProductId: i.ProductId, VariantId: i.VariantId}
+}
+
+// IsSubOrder reports whether the order is a merchant's part of a buyer's order
+func (o *Order) IsSubOrder() bool {
+	return o.ParentId != nil
+}
+
+// Items returns the order lines, for sub-orders only the lines of their merchant
+func (o *Order) Items() []OrderItem {
+	if o.IsSubOrder() {
+		return o.SubOrderItems
+	}
+	return o.OrderItems
+}
+
+// RefundableQuantity is the quantity that has not been refunded yet
+func (i *OrderItem) RefundableQuantity() uint64 {
+	return i.Quantity - i.RefundedQuantity
+}
+
+// ReturnableQuantity is the quantity that can still be sent back, refunded units and
+// units that are already part of a return are excluded
+func (i *OrderItem) ReturnableQuantity() uint64 {
+	if i.ReturnedQuantity >= i.RefundableQuantity() {
+		return 0
+	}
+	return i.RefundableQuantity() - i.ReturnedQuantity
+}
+
+// ShippableQuantity is the quantity that still has to be shipped, refunded units are not shipped
+func (i *OrderItem) ShippableQuantity() uint64 {
+	if i.ShippedQuantity >= i.RefundableQuantity() {
+		return 0
+	}
+	return i.RefundableQuantity() - i.ShippedQuantity
+}
+
+// IsFulfilled reports whether the order is on its way to the buyer or already delivered
+func (o *Order) IsFulfilled() bool {
+	return o.Status == OrderStatusShipped || o.Status == OrderStatusDelivered
 }
@@ -1,18 +1,81 @@
 package models
 
 import (
+	"time"
+
 	"github.com/lib/pq"
 )
 
+// ProductStatus is the lifecycle state of a product
+type ProductStatus string
+
+const (
+	ProductStatusDraft     ProductStatus = "draft"
+	ProductStatusPublished ProductStatus = "published"
+	ProductStatusArchived  ProductStatus = "archived"
+	ProductStatusScheduled ProductStatus = "scheduled" // Published once PublishAt has passed
+)
+
+// ProductStatuses lists every product status
+var ProductStatuses = []ProductStatus{
+	ProductStatusDraft,
+	ProductStatusPublished,
+	ProductStatusArchived,
+	ProductStatusScheduled,
+}
+
 type Product struct {
-	Id              uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name            string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price           float32        `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
-	Inventory       uint64         `protobuf:"varint,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	Description     string         `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Images          pq.StringArray `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty" gorm:"type:text[]"` // [bucketname]
-	StripePriceId   string         `protobuf:"bytes,7,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
-	StripeProductId string         `protobuf:"bytes,8,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
-	MerchantId      uint64         `protobuf:"varint,9,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
-	IsDeleted       bool           `protobuf:"varint,10,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	Id              uint64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PriceMinor      int64                 `protobuf:"varint,11,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"` // In minor units of Currency
+	Currency        string                `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty" gorm:"type:char(3)"`
+	Inventory       uint64                `protobuf:"varint,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	Description     string                `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Images          pq.StringArray        `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty" gorm:"type:text[]"` // [bucketname]
+	StripePriceId   string                `protobuf:"bytes,7,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
+	StripeProductId string                `protobuf:"bytes,8,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
+	MerchantId      uint64                `protobuf:"varint,9,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	IsDeleted       bool                  `protobuf:"varint,10,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	DeletedAt       *time.Time            `json:"deleted_at,omitempty"`                                                                    // When it was soft deleted, purged after the retention period
+	Prices          []ProductPrice        `protobuf:"bytes,13,rep,name=prices,proto3" json:"prices,omitempty" gorm:"foreignKey:ProductId"` // Prices in currencies other than Currency
+	Options         []ProductOption       `protobuf:"bytes,14,rep,name=options,proto3" json:"options,omitempty" gorm:"foreignKey:ProductId"`
+	Variants        []ProductVariant      `protobuf:"bytes,15,rep,name=variants,proto3" json:"variants,omitempty" gorm:"foreignKey:ProductId"`
+	Categories      []Category            `protobuf:"bytes,16,rep,name=categories,proto3" json:"categories,omitempty" gorm:"many2many:product_categories"`
+	Specifications  ProductSpecifications `json:"specifications" gorm:"embedded"`
+	Attributes      []ProductAttribute    `json:"attributes,omitempty" gorm:"foreignKey:ProductId"`
+	CreatedAt       time.Time             `json:"created_at" gorm:"index;autoCreateTime;default:CURRENT_TIMESTAMP"`
+	Status          ProductStatus         `json:"status" gorm:"type:varchar(16);default:'published';index"`
+	PublishAt       *time.Time            `json:"publish_at,omitempty"` // When a scheduled product is published
+}
+
+// ProductPrice is the price of a product in an additional currency
+type ProductPrice struct {
+	ProductId     uint64 `json:"product_id" gorm:"primaryKey"`
+	Currency      string `json:"currency" gorm:"primaryKey;type:char(3)"`
+	PriceMinor    int64  `json:"price_minor"`
+	StripePriceId string `json:"stripe_price_id"`
+}
+
+// PriceIn returns the product's price and Stripe price in the currency, if it is sold in it
+func (p *Product) PriceIn(currency string) (int64, string, bool) {
+	if p.Currency == currency {
+		return p.PriceMinor, p.StripePriceId, true
+	}
+	for _, price := range p.Prices {
+		if price.Currency == currency {
+			return price.PriceMinor, price.StripePriceId, true
+		}
+	}
+	return 0, "", false
+}
+
+// IsPublished tells if buyers can see and order the product at the given time
+func (p *Product) IsPublished(now time.Time) bool {
+	switch p.Status {
+	case ProductStatusPublished:
+		return true
+	case ProductStatusScheduled:
+		return p.PublishAt != nil && !p.PublishAt.After(now)
+	}
+	return false
 }
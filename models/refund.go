@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+type RefundStatus string
+
+var (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+	RefundStatusCancelled RefundStatus = "canceled"
+)
+
+// Refund represents money given back to the buyer for (part of) an order
+type Refund struct {
+	Id             uint64       `json:"id" gorm:"primaryKey"`
+	OrderId        uint64       `json:"order_id" gorm:"index"`
+	StripeRefundId string       `json:"stripe_refund_id"`
+	AmountMinor    int64        `json:"amount_minor"` // In minor units of Currency
+	Currency       string       `json:"currency" gorm:"type:char(3)"`
+	Reason         string       `json:"reason"`
+	Status         RefundStatus `json:"status" gorm:"default:pending"`
+	Restocked      bool         `json:"restocked"`
+	Items          []RefundItem `json:"items" gorm:"foreignKey:RefundId"`
+	CreatedAt      time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// RefundItem represents the refunded quantity of an order item
+type RefundItem struct {
+	RefundId    uint64 `json:"refund_id" gorm:"primaryKey"`
+	ProductId   uint64 `json:"product_id" gorm:"primaryKey"`
+	VariantId   uint64 `json:"variant_id" gorm:"primaryKey;autoIncrement:false;default:0"`
+	Quantity    uint64 `json:"quantity"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+// Key returns the order line the item refunds
+func (i *RefundItem) Key() LineKey {
+	return LineKey{ProductId: i.ProductId, VariantId: i.VariantId}
+}
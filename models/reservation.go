@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Reservation holds stock of a product, or of one of its variants, for an unpaid order.
+// Stock buyers can take is the inventory minus the reservations that have not expired yet.
+// Reservations are turned into an inventory decrement when the order is paid,
+// and deleted when it is cancelled or they expire.
+type Reservation struct {
+	Id        uint64    `json:"id" gorm:"primaryKey"`
+	OrderId   uint64    `json:"order_id" gorm:"index"`
+	ProductId uint64    `json:"product_id" gorm:"index:idx_reservations_line"`
+	VariantId uint64    `json:"variant_id" gorm:"index:idx_reservations_line;default:0"` // 0 for products without variants
+	Quantity  uint64    `json:"quantity"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Key returns the line the stock is held for
+func (r *Reservation) Key() LineKey {
+	return LineKey{ProductId: r.ProductId, VariantId: r.VariantId}
+}
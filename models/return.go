@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+type ReturnStatus string
+
+var (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+)
+
+// Return represents a buyer's request to send back items of a merchant's sub-order
+type Return struct {
+	Id         uint64               `json:"id" gorm:"primaryKey"`
+	OrderId    uint64               `json:"order_id" gorm:"index"` // The buyer's order
+	SubOrderId uint64               `json:"sub_order_id" gorm:"index"`
+	UserId     uint64               `json:"user_id" gorm:"index"`
+	MerchantId uint64               `json:"merchant_id" gorm:"index"`
+	Reason     string               `json:"reason"`
+	Status     ReturnStatus         `json:"status" gorm:"default:requested"`
+	Restocked  bool                 `json:"restocked"`
+	RefundId   *uint64              `json:"refund_id,omitempty"` // Set when the approval refunded the buyer
+	Items      []ReturnItem         `json:"items" gorm:"foreignKey:ReturnId"`
+	History    []ReturnStatusChange `json:"history" gorm:"foreignKey:ReturnId"`
+	CreatedAt  time.Time            `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ReturnItem represents the quantity of an order item being returned
+type ReturnItem struct {
+	ReturnId  uint64 `json:"return_id" gorm:"primaryKey"`
+	ProductId uint64 `json:"product_id" gorm:"primaryKey"`
+	VariantId uint64 `json:"variant_id" gorm:"primaryKey;autoIncrement:false;default:0"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+// Key returns the order line the item returns
+func (i *ReturnItem) Key() LineKey {
+	return LineKey{ProductId: i.ProductId, VariantId: i.VariantId}
+}
+
+// ReturnStatusChange records every status a return went through and who moved it there
+type ReturnStatusChange struct {
+	Id        uint64       `json:"id" gorm:"primaryKey"`
+	ReturnId  uint64       `json:"return_id" gorm:"index"`
+	Status    ReturnStatus `json:"status"`
+	Note      string       `json:"note"`
+	ActorId   uint64       `json:"actor_id"`
+	CreatedAt time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+type ShipmentStatus string
+
+var (
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+)
+
+// Shipment represents a parcel a merchant sent out for their sub-order
+type Shipment struct {
+	Id             uint64         `json:"id" gorm:"primaryKey"`
+	OrderId        uint64         `json:"order_id" gorm:"index"` // The merchant sub-order
+	MerchantId     uint64         `json:"merchant_id" gorm:"index"`
+	Carrier        string         `json:"carrier"`
+	TrackingNumber string         `json:"tracking_number"`
+	Status         ShipmentStatus `json:"status" gorm:"default:shipped"`
+	Items          []ShipmentItem `json:"items" gorm:"foreignKey:ShipmentId"`
+	ShippedAt      *time.Time     `json:"shipped_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ShipmentItem represents the quantity of an order item contained in a shipment
+type ShipmentItem struct {
+	ShipmentId uint64 `json:"shipment_id" gorm:"primaryKey"`
+	ProductId  uint64 `json:"product_id" gorm:"primaryKey"`
+	VariantId  uint64 `json:"variant_id" gorm:"primaryKey;autoIncrement:false;default:0"`
+	Quantity   uint64 `json:"quantity"`
+}
+
+// Key returns the order line the item ships
+func (i *ShipmentItem) Key() LineKey {
+	return LineKey{ProductId: i.ProductId, VariantId: i.VariantId}
+}
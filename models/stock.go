@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type StockMovementType string
+
+var (
+	StockMovementOrderPlaced      StockMovementType = "order_placed"      // stock taken for a paid order
+	StockMovementOrderCancelled   StockMovementType = "order_cancelled"   // stock of a cancelled order put back
+	StockMovementManualAdjustment StockMovementType = "manual_adjustment" // inventory set by the merchant
+	StockMovementReturnRestock    StockMovementType = "return_restock"    // returned items put back
+	StockMovementRefundRestock    StockMovementType = "refund_restock"    // refunded items put back
+	StockMovementRefundFailed     StockMovementType = "refund_failed"     // items put back by a refund that failed taken out again
+	StockMovementImport           StockMovementType = "import"            // initial stock of a new product or variant
+)
+
+// StockMovementTypes lists every type of stock movement
+var StockMovementTypes = []StockMovementType{
+	StockMovementOrderPlaced,
+	StockMovementOrderCancelled,
+	StockMovementManualAdjustment,
+	StockMovementReturnRestock,
+	StockMovementRefundRestock,
+	StockMovementRefundFailed,
+	StockMovementImport,
+}
+
+// StockActorSystem is the actor of stock movements made by background jobs and payment webhooks
+const StockActorSystem = "system"
+
+// UserStockActor is the actor of stock movements made on behalf of a user, buyer or merchant
+func UserStockActor(userId uint64) string {
+	return fmt.Sprintf("user:%d", userId)
+}
+
+// StockMovement records a change to the inventory of a product, or of one of its variants.
+// Entries are never updated or deleted, so merchants can reconcile the inventory against them.
+type StockMovement struct {
+	Id          uint64            `json:"id" gorm:"primaryKey"`
+	ProductId   uint64            `json:"product_id" gorm:"index:idx_stock_movements_line"`
+	VariantId   uint64            `json:"variant_id" gorm:"index:idx_stock_movements_line;default:0"` // 0 for products without variants
+	Type        StockMovementType `json:"type" gorm:"type:varchar(32)"`
+	Delta       int64             `json:"delta"`
+	Quantity    uint64            `json:"quantity"`     // inventory after the movement
+	Actor       string            `json:"actor"`        // user:<id> or system
+	ReferenceId uint64            `json:"reference_id"` // order, return or refund ID depending on the type, 0 otherwise
+	CreatedAt   time.Time         `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// StripeEvent records a Stripe webhook event that has already been processed
+type StripeEvent struct {
+	Id        string    `json:"id" gorm:"primaryKey"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ProductOption is an axis a product varies along, e.g. size with the values S, M and L
+type ProductOption struct {
+	Id        uint64         `json:"id" gorm:"primaryKey"`
+	ProductId uint64         `json:"product_id" gorm:"index"`
+	Name      string         `json:"name"`
+	Position  uint32         `json:"position"`
+	Values    pq.StringArray `json:"values" gorm:"type:text[]"`
+}
+
+// ProductVariant is a purchasable combination of option values with its own stock
+type ProductVariant struct {
+	Id            uint64         `json:"id" gorm:"primaryKey"`
+	ProductId     uint64         `json:"product_id" gorm:"index"`
+	Sku           string         `json:"sku" gorm:"uniqueIndex"`
+	OptionValues  pq.StringArray `json:"option_values" gorm:"type:text[]"` // One value per option, in option position order
+	PriceMinor    int64          `json:"price_minor"`                      // In the product's currency, 0 to use the product's price
+	Inventory     uint64         `json:"inventory"`
+	Images        pq.StringArray `json:"images" gorm:"type:text[]"`
+	StripePriceId string         `json:"stripe_price_id"` // Only set when the variant has its own price
+	IsDeleted     bool           `json:"is_deleted" gorm:"default:false"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// VariantPriceIn returns the price and Stripe price of the variant in the currency.
+// Variants with their own price are only sold in the product's base currency.
+func (p *Product) VariantPriceIn(variant *ProductVariant, currency string) (int64, string, bool) {
+	if variant == nil || variant.PriceMinor == 0 {
+		return p.PriceIn(currency)
+	}
+	if currency != p.Currency {
+		return 0, "", false
+	}
+	return variant.PriceMinor, variant.StripePriceId, true
+}
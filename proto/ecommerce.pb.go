@@ -22,9 +22,11 @@ const (
 )
 
 type CartItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Quantity uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// required for products that have variants
+	VariantId     uint64 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +75,13 @@ func (x *CartItem) GetQuantity() uint64 {
 	return 0
 }
 
+func (x *CartItem) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
 type AddItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
@@ -414,19 +423,40 @@ func (*Empty) Descriptor() ([]byte, []int) {
 }
 
 type Product struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Id          uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price       float32                `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
-	Inventory   uint64                 `protobuf:"varint,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// deprecated, use price_minor and currency
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Price       float32 `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+	Inventory   uint64  `protobuf:"varint,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	Description string  `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	// @gotags: gorm:"type:varchar(255)[]"
 	Images          []string `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty" gorm:"type:varchar(255)[]"` // [bucketname]
 	StripePriceId   string   `protobuf:"bytes,7,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
 	StripeProductId string   `protobuf:"bytes,8,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
 	MerchantId      uint64   `protobuf:"varint,9,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// price in the smallest unit of the currency, e.g. cents
+	PriceMinor int64 `protobuf:"varint,11,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	// ISO 4217 currency code
+	Currency string `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	// prices in currencies other than the base currency above
+	Prices         []*ProductPrice        `protobuf:"bytes,13,rep,name=prices,proto3" json:"prices,omitempty"`
+	Options        []*ProductOption       `protobuf:"bytes,14,rep,name=options,proto3" json:"options,omitempty"`
+	Variants       []*ProductVariant      `protobuf:"bytes,15,rep,name=variants,proto3" json:"variants,omitempty"`
+	Categories     []*Category            `protobuf:"bytes,16,rep,name=categories,proto3" json:"categories,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Specifications *ProductSpecifications `protobuf:"bytes,18,opt,name=specifications,proto3" json:"specifications,omitempty"`
+	// draft, published, archived or scheduled
+	Status string `protobuf:"bytes,19,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339 timestamp a scheduled product is published at
+	PublishAt string `protobuf:"bytes,20,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	// deleted products are only listed to their merchant and can be restored until they are purged
+	IsDeleted     bool   `protobuf:"varint,10,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	DeletedAt     string `protobuf:"bytes,21,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Product) Reset() {
@@ -473,6 +503,7 @@ func (x *Product) GetName() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in ecommerce.proto.
 func (x *Product) GetPrice() float32 {
 	if x != nil {
 		return x.Price
@@ -522,136 +553,118 @@ func (x *Product) GetMerchantId() uint64 {
 	return 0
 }
 
-type UpdateProductImagesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ImageData     []byte                 `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	Id            uint64                 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Product) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
 }
 
-func (x *UpdateProductImagesRequest) Reset() {
-	*x = UpdateProductImagesRequest{}
-	mi := &file_ecommerce_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Product) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
 }
 
-func (x *UpdateProductImagesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Product) GetPrices() []*ProductPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
 }
 
-func (*UpdateProductImagesRequest) ProtoMessage() {}
-
-func (x *UpdateProductImagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[9]
+func (x *Product) GetOptions() []*ProductOption {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Options
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use UpdateProductImagesRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductImagesRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{9}
+func (x *Product) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
 }
 
-func (x *UpdateProductImagesRequest) GetImageData() []byte {
+func (x *Product) GetCategories() []*Category {
 	if x != nil {
-		return x.ImageData
+		return x.Categories
 	}
 	return nil
 }
 
-func (x *UpdateProductImagesRequest) GetFilename() string {
+func (x *Product) GetCreatedAt() string {
 	if x != nil {
-		return x.Filename
+		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *UpdateProductImagesRequest) GetId() uint64 {
+func (x *Product) GetSpecifications() *ProductSpecifications {
 	if x != nil {
-		return x.Id
+		return x.Specifications
 	}
-	return 0
-}
-
-type UpdateProductImagesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UploadedFiles []string               `protobuf:"bytes,1,rep,name=uploaded_files,json=uploadedFiles,proto3" json:"uploaded_files,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateProductImagesResponse) Reset() {
-	*x = UpdateProductImagesResponse{}
-	mi := &file_ecommerce_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return nil
 }
 
-func (x *UpdateProductImagesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Product) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func (*UpdateProductImagesResponse) ProtoMessage() {}
-
-func (x *UpdateProductImagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[10]
+func (x *Product) GetPublishAt() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PublishAt
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateProductImagesResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductImagesResponse) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{10}
+func (x *Product) GetIsDeleted() bool {
+	if x != nil {
+		return x.IsDeleted
+	}
+	return false
 }
 
-func (x *UpdateProductImagesResponse) GetUploadedFiles() []string {
+func (x *Product) GetDeletedAt() string {
 	if x != nil {
-		return x.UploadedFiles
+		return x.DeletedAt
 	}
-	return nil
+	return ""
 }
 
-type CreateProductRequest struct {
+type ProductSpecifications struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Price         float32                `protobuf:"fixed32,2,opt,name=price,proto3" json:"price,omitempty"`
-	Inventory     uint64                 `protobuf:"varint,3,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	MerchantId    uint64                 `protobuf:"varint,5,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Brand         string                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	Material      string                 `protobuf:"bytes,2,opt,name=material,proto3" json:"material,omitempty"`
+	WeightGrams   uint64                 `protobuf:"varint,3,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	LengthMm      uint64                 `protobuf:"varint,4,opt,name=length_mm,json=lengthMm,proto3" json:"length_mm,omitempty"`
+	WidthMm       uint64                 `protobuf:"varint,5,opt,name=width_mm,json=widthMm,proto3" json:"width_mm,omitempty"`
+	HeightMm      uint64                 `protobuf:"varint,6,opt,name=height_mm,json=heightMm,proto3" json:"height_mm,omitempty"`
+	Attributes    []*ProductAttribute    `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
-	mi := &file_ecommerce_proto_msgTypes[11]
+func (x *ProductSpecifications) Reset() {
+	*x = ProductSpecifications{}
+	mi := &file_ecommerce_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductRequest) String() string {
+func (x *ProductSpecifications) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
+func (*ProductSpecifications) ProtoMessage() {}
 
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[11]
+func (x *ProductSpecifications) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,76 +675,88 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ProductSpecifications.ProtoReflect.Descriptor instead.
+func (*ProductSpecifications) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateProductRequest) GetName() string {
+func (x *ProductSpecifications) GetBrand() string {
 	if x != nil {
-		return x.Name
+		return x.Brand
 	}
 	return ""
 }
 
-func (x *CreateProductRequest) GetPrice() float32 {
+func (x *ProductSpecifications) GetMaterial() string {
 	if x != nil {
-		return x.Price
+		return x.Material
+	}
+	return ""
+}
+
+func (x *ProductSpecifications) GetWeightGrams() uint64 {
+	if x != nil {
+		return x.WeightGrams
 	}
 	return 0
 }
 
-func (x *CreateProductRequest) GetInventory() uint64 {
+func (x *ProductSpecifications) GetLengthMm() uint64 {
 	if x != nil {
-		return x.Inventory
+		return x.LengthMm
 	}
 	return 0
 }
 
-func (x *CreateProductRequest) GetDescription() string {
+func (x *ProductSpecifications) GetWidthMm() uint64 {
 	if x != nil {
-		return x.Description
+		return x.WidthMm
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateProductRequest) GetMerchantId() uint64 {
+func (x *ProductSpecifications) GetHeightMm() uint64 {
 	if x != nil {
-		return x.MerchantId
+		return x.HeightMm
 	}
 	return 0
 }
 
-type UpdateProductRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price           float32                `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
-	Inventory       uint64                 `protobuf:"varint,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Images          []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
-	StripePriceId   string                 `protobuf:"bytes,7,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
-	StripeProductId string                 `protobuf:"bytes,8,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
-	MerchantId      uint64                 `protobuf:"varint,9,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *ProductSpecifications) GetAttributes() []*ProductAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
-	mi := &file_ecommerce_proto_msgTypes[12]
+// ProductAttribute is a custom specification, only the value field matching the type is used
+type ProductAttribute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// string (default), number or boolean
+	Type          string  `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	StringValue   string  `protobuf:"bytes,3,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	NumberValue   float64 `protobuf:"fixed64,4,opt,name=number_value,json=numberValue,proto3" json:"number_value,omitempty"`
+	BoolValue     bool    `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAttribute) Reset() {
+	*x = ProductAttribute{}
+	mi := &file_ecommerce_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *ProductAttribute) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*ProductAttribute) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[12]
+func (x *ProductAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -742,97 +767,75 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *UpdateProductRequest) GetId() uint64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+// Deprecated: Use ProductAttribute.ProtoReflect.Descriptor instead.
+func (*ProductAttribute) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateProductRequest) GetName() string {
+func (x *ProductAttribute) GetKey() string {
 	if x != nil {
-		return x.Name
+		return x.Key
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetPrice() float32 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-func (x *UpdateProductRequest) GetInventory() uint64 {
-	if x != nil {
-		return x.Inventory
-	}
-	return 0
-}
-
-func (x *UpdateProductRequest) GetDescription() string {
+func (x *ProductAttribute) GetType() string {
 	if x != nil {
-		return x.Description
+		return x.Type
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetImages() []string {
-	if x != nil {
-		return x.Images
-	}
-	return nil
-}
-
-func (x *UpdateProductRequest) GetStripePriceId() string {
+func (x *ProductAttribute) GetStringValue() string {
 	if x != nil {
-		return x.StripePriceId
+		return x.StringValue
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetStripeProductId() string {
+func (x *ProductAttribute) GetNumberValue() float64 {
 	if x != nil {
-		return x.StripeProductId
+		return x.NumberValue
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetMerchantId() uint64 {
+func (x *ProductAttribute) GetBoolValue() bool {
 	if x != nil {
-		return x.MerchantId
+		return x.BoolValue
 	}
-	return 0
+	return false
 }
 
-type DeleteProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+// AttributeFilter matches products by brand, material or a custom attribute
+type AttributeFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// brand, material or the key of a custom attribute
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// any of the values, booleans as true or false
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// range of number attributes, 0 for no bound
+	Min           float64 `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64 `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
-	mi := &file_ecommerce_proto_msgTypes[13]
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	mi := &file_ecommerce_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *AttributeFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*AttributeFilter) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[13]
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,49 +846,63 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DeleteProductRequest) GetId() uint64 {
+func (x *AttributeFilter) GetKey() string {
 	if x != nil {
-		return x.Id
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *AttributeFilter) GetMin() float64 {
+	if x != nil {
+		return x.Min
 	}
 	return 0
 }
 
-func (x *DeleteProductRequest) GetMerchantId() uint64 {
+func (x *AttributeFilter) GetMax() float64 {
 	if x != nil {
-		return x.MerchantId
+		return x.Max
 	}
 	return 0
 }
 
-type ListProductsResponse struct {
+type ProductPrice struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Cursor        uint64                 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Total         uint64                 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	PriceMinor    int64                  `protobuf:"varint,2,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	StripePriceId string                 `protobuf:"bytes,3,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_ecommerce_proto_msgTypes[14]
+func (x *ProductPrice) Reset() {
+	*x = ProductPrice{}
+	mi := &file_ecommerce_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *ProductPrice) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*ProductPrice) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[14]
+func (x *ProductPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,56 +913,56 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ProductPrice.ProtoReflect.Descriptor instead.
+func (*ProductPrice) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *ProductPrice) GetCurrency() string {
 	if x != nil {
-		return x.Products
+		return x.Currency
 	}
-	return nil
+	return ""
 }
 
-func (x *ListProductsResponse) GetCursor() uint64 {
+func (x *ProductPrice) GetPriceMinor() int64 {
 	if x != nil {
-		return x.Cursor
+		return x.PriceMinor
 	}
 	return 0
 }
 
-func (x *ListProductsResponse) GetTotal() uint64 {
+func (x *ProductPrice) GetStripePriceId() string {
 	if x != nil {
-		return x.Total
+		return x.StripePriceId
 	}
-	return 0
+	return ""
 }
 
-type ListProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cursor        uint64                 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Limit         uint64                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	MerchantId    uint64                 `protobuf:"varint,3,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+type ProductOption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. size or colour
+	Name          string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
-	mi := &file_ecommerce_proto_msgTypes[15]
+func (x *ProductOption) Reset() {
+	*x = ProductOption{}
+	mi := &file_ecommerce_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *ProductOption) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*ProductOption) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[15]
+func (x *ProductOption) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -956,54 +973,56 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *ListProductsRequest) GetCursor() uint64 {
-	if x != nil {
-		return x.Cursor
-	}
-	return 0
+// Deprecated: Use ProductOption.ProtoReflect.Descriptor instead.
+func (*ProductOption) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListProductsRequest) GetLimit() uint64 {
+func (x *ProductOption) GetName() string {
 	if x != nil {
-		return x.Limit
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsRequest) GetMerchantId() uint64 {
+func (x *ProductOption) GetValues() []string {
 	if x != nil {
-		return x.MerchantId
+		return x.Values
 	}
-	return 0
+	return nil
 }
 
-type GetProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+type ProductVariant struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId uint64                 `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku       string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	// one value per product option, in the same order as the options
+	OptionValues []string `protobuf:"bytes,4,rep,name=option_values,json=optionValues,proto3" json:"option_values,omitempty"`
+	// in the product's base currency, 0 to use the product's price
+	PriceMinor    int64    `protobuf:"varint,5,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Inventory     uint64   `protobuf:"varint,6,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	Images        []string `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
+	StripePriceId string   `protobuf:"bytes,8,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_ecommerce_proto_msgTypes[16]
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_ecommerce_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *ProductVariant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*ProductVariant) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[16]
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1014,41 +1033,98 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetProductRequest) GetId() uint64 {
+func (x *ProductVariant) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type ValidateProductInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+func (x *ProductVariant) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetOptionValues() []string {
+	if x != nil {
+		return x.OptionValues
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetInventory() uint64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetStripePriceId() string {
+	if x != nil {
+		return x.StripePriceId
+	}
+	return ""
+}
+
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 0 for top level categories
+	ParentId    uint64 `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Slug        string `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt   string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// platform commission in basis points on sales in the category, 0 when the default applies
+	CommissionBps uint32 `protobuf:"varint,8,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateProductInventoryRequest) Reset() {
-	*x = ValidateProductInventoryRequest{}
-	mi := &file_ecommerce_proto_msgTypes[17]
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_ecommerce_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateProductInventoryRequest) String() string {
+func (x *Category) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateProductInventoryRequest) ProtoMessage() {}
+func (*Category) ProtoMessage() {}
 
-func (x *ValidateProductInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[17]
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,47 +1135,95 @@ func (x *ValidateProductInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateProductInventoryRequest.ProtoReflect.Descriptor instead.
-func (*ValidateProductInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ValidateProductInventoryRequest) GetProductId() uint64 {
+func (x *Category) GetId() uint64 {
 	if x != nil {
-		return x.ProductId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *ValidateProductInventoryRequest) GetQuantity() uint64 {
+func (x *Category) GetParentId() uint64 {
 	if x != nil {
-		return x.Quantity
+		return x.ParentId
 	}
 	return 0
 }
 
-type ValidateProductInventoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Category) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Category) GetCommissionBps() uint32 {
+	if x != nil {
+		return x.CommissionBps
+	}
+	return 0
+}
+
+type CreateCategoryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ParentId uint64                 `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// generated from the name when empty
+	Slug        string `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// 0 to charge the default commission
+	CommissionBps uint32 `protobuf:"varint,5,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateProductInventoryResponse) Reset() {
-	*x = ValidateProductInventoryResponse{}
-	mi := &file_ecommerce_proto_msgTypes[18]
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_ecommerce_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateProductInventoryResponse) String() string {
+func (x *CreateCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateProductInventoryResponse) ProtoMessage() {}
+func (*CreateCategoryRequest) ProtoMessage() {}
 
-func (x *ValidateProductInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[18]
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,44 +1234,75 @@ func (x *ValidateProductInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateProductInventoryResponse.ProtoReflect.Descriptor instead.
-func (*ValidateProductInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ValidateProductInventoryResponse) GetValid() bool {
+func (x *CreateCategoryRequest) GetParentId() uint64 {
 	if x != nil {
-		return x.Valid
+		return x.ParentId
 	}
-	return false
+	return 0
 }
 
-type PlaceOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserEmail     string                 `protobuf:"bytes,3,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
-	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
-	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetCommissionBps() uint32 {
+	if x != nil {
+		return x.CommissionBps
+	}
+	return 0
+}
+
+// replaces all fields of the category, a parent_id of 0 moves it to the top level
+type UpdateCategoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId    uint64                 `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Slug        string                 `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// 0 to charge the default commission
+	CommissionBps uint32 `protobuf:"varint,6,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlaceOrderRequest) Reset() {
-	*x = PlaceOrderRequest{}
-	mi := &file_ecommerce_proto_msgTypes[19]
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_ecommerce_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlaceOrderRequest) String() string {
+func (x *UpdateCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlaceOrderRequest) ProtoMessage() {}
+func (*UpdateCategoryRequest) ProtoMessage() {}
 
-func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[19]
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1158,68 +1313,75 @@ func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
-func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PlaceOrderRequest) GetSessionId() string {
+func (x *UpdateCategoryRequest) GetId() uint64 {
 	if x != nil {
-		return x.SessionId
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *PlaceOrderRequest) GetUserId() uint64 {
+func (x *UpdateCategoryRequest) GetParentId() uint64 {
 	if x != nil {
-		return x.UserId
+		return x.ParentId
 	}
 	return 0
 }
 
-func (x *PlaceOrderRequest) GetUserEmail() string {
+func (x *UpdateCategoryRequest) GetName() string {
 	if x != nil {
-		return x.UserEmail
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PlaceOrderRequest) GetAddress() string {
+func (x *UpdateCategoryRequest) GetSlug() string {
 	if x != nil {
-		return x.Address
+		return x.Slug
 	}
 	return ""
 }
 
-func (x *PlaceOrderRequest) GetCountry() string {
+func (x *UpdateCategoryRequest) GetDescription() string {
 	if x != nil {
-		return x.Country
+		return x.Description
 	}
 	return ""
 }
 
-type PlaceOrderResponse struct {
+func (x *UpdateCategoryRequest) GetCommissionBps() uint32 {
+	if x != nil {
+		return x.CommissionBps
+	}
+	return 0
+}
+
+type DeleteCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CheckoutUrl   string                 `protobuf:"bytes,1,opt,name=checkout_url,json=checkoutUrl,proto3" json:"checkout_url,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlaceOrderResponse) Reset() {
-	*x = PlaceOrderResponse{}
-	mi := &file_ecommerce_proto_msgTypes[20]
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_ecommerce_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlaceOrderResponse) String() string {
+func (x *DeleteCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlaceOrderResponse) ProtoMessage() {}
+func (*DeleteCategoryRequest) ProtoMessage() {}
 
-func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[20]
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,47 +1392,42 @@ func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
-func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PlaceOrderResponse) GetCheckoutUrl() string {
+func (x *DeleteCategoryRequest) GetId() uint64 {
 	if x != nil {
-		return x.CheckoutUrl
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ProductId     uint64                 `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      uint64                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
-	ProductName   string                 `protobuf:"bytes,5,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	ProductImage  string                 `protobuf:"bytes,6,opt,name=product_image,json=productImage,proto3" json:"product_image,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+type GetCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// looked up when id is 0
+	Slug          string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_ecommerce_proto_msgTypes[21]
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_ecommerce_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *GetCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*GetCategoryRequest) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[21]
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1281,99 +1438,4140 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetCategoryRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type ListCategoriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only list the direct children of the category
+	ParentId uint64 `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// list the whole tree, parent_id is ignored
+	All           bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_ecommerce_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListCategoriesRequest) GetParentId() uint64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *ListCategoriesRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_ecommerce_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
 	return file_ecommerce_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *OrderItem) GetOrderId() uint64 {
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// replaces the categories of the product
+type SetProductCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	CategoryIds   []uint64               `protobuf:"varint,3,rep,packed,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductCategoriesRequest) Reset() {
+	*x = SetProductCategoriesRequest{}
+	mi := &file_ecommerce_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductCategoriesRequest) ProtoMessage() {}
+
+func (x *SetProductCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*SetProductCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetProductCategoriesRequest) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SetProductCategoriesRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *SetProductCategoriesRequest) GetCategoryIds() []uint64 {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+type SetProductOptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Options       []*ProductOption       `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductOptionsRequest) Reset() {
+	*x = SetProductOptionsRequest{}
+	mi := &file_ecommerce_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductOptionsRequest) ProtoMessage() {}
+
+func (x *SetProductOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductOptionsRequest.ProtoReflect.Descriptor instead.
+func (*SetProductOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetProductOptionsRequest) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SetProductOptionsRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *SetProductOptionsRequest) GetOptions() []*ProductOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CreateProductVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	OptionValues  []string               `protobuf:"bytes,4,rep,name=option_values,json=optionValues,proto3" json:"option_values,omitempty"`
+	PriceMinor    int64                  `protobuf:"varint,5,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Inventory     uint64                 `protobuf:"varint,6,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductVariantRequest) Reset() {
+	*x = CreateProductVariantRequest{}
+	mi := &file_ecommerce_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductVariantRequest) ProtoMessage() {}
+
+func (x *CreateProductVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductVariantRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductVariantRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateProductVariantRequest) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *CreateProductVariantRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *CreateProductVariantRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateProductVariantRequest) GetOptionValues() []string {
+	if x != nil {
+		return x.OptionValues
+	}
+	return nil
+}
+
+func (x *CreateProductVariantRequest) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *CreateProductVariantRequest) GetInventory() uint64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return 0
+}
+
+// replaces all fields of the variant
+type UpdateProductVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	OptionValues  []string               `protobuf:"bytes,4,rep,name=option_values,json=optionValues,proto3" json:"option_values,omitempty"`
+	PriceMinor    int64                  `protobuf:"varint,5,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Inventory     uint64                 `protobuf:"varint,6,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductVariantRequest) Reset() {
+	*x = UpdateProductVariantRequest{}
+	mi := &file_ecommerce_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductVariantRequest) ProtoMessage() {}
+
+func (x *UpdateProductVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductVariantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductVariantRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateProductVariantRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProductVariantRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *UpdateProductVariantRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateProductVariantRequest) GetOptionValues() []string {
+	if x != nil {
+		return x.OptionValues
+	}
+	return nil
+}
+
+func (x *UpdateProductVariantRequest) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *UpdateProductVariantRequest) GetInventory() uint64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return 0
+}
+
+type DeleteProductVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductVariantRequest) Reset() {
+	*x = DeleteProductVariantRequest{}
+	mi := &file_ecommerce_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductVariantRequest) ProtoMessage() {}
+
+func (x *DeleteProductVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductVariantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductVariantRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteProductVariantRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteProductVariantRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+type UpdateProductImagesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ImageData []byte                 `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	Filename  string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Id        uint64                 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	// set to upload the images of a variant of the product
+	VariantId     uint64 `protobuf:"varint,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductImagesRequest) Reset() {
+	*x = UpdateProductImagesRequest{}
+	mi := &file_ecommerce_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductImagesRequest) ProtoMessage() {}
+
+func (x *UpdateProductImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductImagesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductImagesRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateProductImagesRequest) GetImageData() []byte {
+	if x != nil {
+		return x.ImageData
+	}
+	return nil
+}
+
+func (x *UpdateProductImagesRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UpdateProductImagesRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProductImagesRequest) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type UpdateProductImagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadedFiles []string               `protobuf:"bytes,1,rep,name=uploaded_files,json=uploadedFiles,proto3" json:"uploaded_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductImagesResponse) Reset() {
+	*x = UpdateProductImagesResponse{}
+	mi := &file_ecommerce_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductImagesResponse) ProtoMessage() {}
+
+func (x *UpdateProductImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductImagesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductImagesResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateProductImagesResponse) GetUploadedFiles() []string {
+	if x != nil {
+		return x.UploadedFiles
+	}
+	return nil
+}
+
+type CreateProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// deprecated, used only when price_minor is not set
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Price       float32 `protobuf:"fixed32,2,opt,name=price,proto3" json:"price,omitempty"`
+	Inventory   uint64  `protobuf:"varint,3,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	Description string  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId uint64 `protobuf:"varint,5,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	PriceMinor int64  `protobuf:"varint,6,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	// defaults to the store currency
+	Currency       string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Prices         []*ProductPrice        `protobuf:"bytes,8,rep,name=prices,proto3" json:"prices,omitempty"`
+	CategoryIds    []uint64               `protobuf:"varint,9,rep,packed,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	Specifications *ProductSpecifications `protobuf:"bytes,10,opt,name=specifications,proto3" json:"specifications,omitempty"`
+	// draft, published (default), archived or scheduled
+	Status string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339 timestamp, required when scheduled
+	PublishAt     string `protobuf:"bytes,12,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_ecommerce_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *CreateProductRequest) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetInventory() uint64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetPrices() []*ProductPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetCategoryIds() []uint64 {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetSpecifications() *ProductSpecifications {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+type UpdateProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// deprecated, used only when price_minor is not set
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Price           float32  `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+	Inventory       uint64   `protobuf:"varint,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	Description     string   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Images          []string `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	StripePriceId   string   `protobuf:"bytes,7,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
+	StripeProductId string   `protobuf:"bytes,8,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId uint64 `protobuf:"varint,9,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	PriceMinor int64  `protobuf:"varint,10,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Currency   string `protobuf:"bytes,11,opt,name=currency,proto3" json:"currency,omitempty"`
+	// added or replaced per currency, a price_minor of 0 removes the currency
+	Prices []*ProductPrice `protobuf:"bytes,12,rep,name=prices,proto3" json:"prices,omitempty"`
+	// replaces all specifications when set
+	Specifications *ProductSpecifications `protobuf:"bytes,13,opt,name=specifications,proto3" json:"specifications,omitempty"`
+	// unchanged when empty
+	Status        string `protobuf:"bytes,14,opt,name=status,proto3" json:"status,omitempty"`
+	PublishAt     string `protobuf:"bytes,15,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_ecommerce_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateProductRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *UpdateProductRequest) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetInventory() uint64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetStripePriceId() string {
+	if x != nil {
+		return x.StripePriceId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetStripeProductId() string {
+	if x != nil {
+		return x.StripeProductId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPrices() []*ProductPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetSpecifications() *ProductSpecifications {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+type DeleteProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId    uint64 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_ecommerce_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteProductRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteProductRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+type ListProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// deprecated, the ID of the last product when sorted by ID, use next_page_token
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Cursor uint64 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Total  uint64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// pass as page_token to get the next page, empty on the last page
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// only set when include_facets was requested
+	Facets        []*Facet `protobuf:"bytes,5,rep,name=facets,proto3" json:"facets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_ecommerce_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *ListProductsResponse) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListProductsResponse) GetFacets() []*Facet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+// Facet counts the products matching the current filters per value of a property
+type Facet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// category, merchant, price, brand, material or attribute:<key> for custom attributes
+	Name          string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []*FacetValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Facet) Reset() {
+	*x = Facet{}
+	mi := &file_ecommerce_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Facet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Facet) ProtoMessage() {}
+
+func (x *Facet) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Facet.ProtoReflect.Descriptor instead.
+func (*Facet) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Facet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Facet) GetValues() []*FacetValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type FacetValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// category or merchant ID, or a price range like 1000-2500 in minor units with an open end for the last bucket
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// display name where there is one, e.g. the category name
+	Label         string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Count         uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetValue) Reset() {
+	*x = FacetValue{}
+	mi := &file_ecommerce_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetValue) ProtoMessage() {}
+
+func (x *FacetValue) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetValue.ProtoReflect.Descriptor instead.
+func (*FacetValue) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FacetValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetValue) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FacetValue) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// deprecated, only used when sorted by ID and page_token is not set
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Cursor     uint64 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit      uint64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	MerchantId uint64 `protobuf:"varint,3,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	// products in the category or any of its descendants
+	CategoryId uint64 `protobuf:"varint,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// price range in minor units of currency, 0 for no bound
+	MinPriceMinor int64 `protobuf:"varint,5,opt,name=min_price_minor,json=minPriceMinor,proto3" json:"min_price_minor,omitempty"`
+	MaxPriceMinor int64 `protobuf:"varint,6,opt,name=max_price_minor,json=maxPriceMinor,proto3" json:"max_price_minor,omitempty"`
+	// currency of the price range and price sorting, defaults to the store currency
+	Currency string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	// only products that have stock, of the product or any of its variants
+	InStock bool `protobuf:"varint,8,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`
+	// RFC 3339 timestamp, only products created after it
+	CreatedAfter string `protobuf:"bytes,9,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// one of id (default), newest, price_asc, price_desc, name
+	Sort          string `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`
+	PageToken     string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	IncludeFacets bool   `protobuf:"varint,12,opt,name=include_facets,json=includeFacets,proto3" json:"include_facets,omitempty"`
+	// products matching all of the filters
+	Attributes    []*AttributeFilter `protobuf:"bytes,13,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_ecommerce_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{35}
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *ListProductsRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetCategoryId() uint64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMinPriceMinor() int64 {
+	if x != nil {
+		return x.MinPriceMinor
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPriceMinor() int64 {
+	if x != nil {
+		return x.MaxPriceMinor
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetIncludeFacets() bool {
+	if x != nil {
+		return x.IncludeFacets
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetAttributes() []*AttributeFilter {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type SearchProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// deprecated, the number of results already returned, use page_token
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Cursor     uint64 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit      uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	MerchantId uint64 `protobuf:"varint,4,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	// products in the category or any of its descendants
+	CategoryId    uint64 `protobuf:"varint,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	IncludeFacets bool   `protobuf:"varint,7,opt,name=include_facets,json=includeFacets,proto3" json:"include_facets,omitempty"`
+	// currency of the price facet, defaults to the store currency
+	Currency      string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_ecommerce_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *SearchProductsRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetCategoryId() uint64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetIncludeFacets() bool {
+	if x != nil {
+		return x.IncludeFacets
+	}
+	return false
+}
+
+func (x *SearchProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// ListMerchantProductsRequest lists a merchant's own products in every state
+type ListMerchantProductsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId uint64                 `protobuf:"varint,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Limit      uint64                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken  string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// one of id (default), newest, price_asc, price_desc, name
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// only products in any of these states, all states when empty
+	Statuses []string `protobuf:"bytes,5,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// also list deleted products that were not purged yet
+	IncludeDeleted bool `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListMerchantProductsRequest) Reset() {
+	*x = ListMerchantProductsRequest{}
+	mi := &file_ecommerce_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantProductsRequest) ProtoMessage() {}
+
+func (x *ListMerchantProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListMerchantProductsRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListMerchantProductsRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *ListMerchantProductsRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMerchantProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMerchantProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListMerchantProductsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListMerchantProductsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type RestoreProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MerchantId    uint64                 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_ecommerce_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RestoreProductRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreProductRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_ecommerce_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetProductRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ValidateProductInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     uint64                 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateProductInventoryRequest) Reset() {
+	*x = ValidateProductInventoryRequest{}
+	mi := &file_ecommerce_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateProductInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateProductInventoryRequest) ProtoMessage() {}
+
+func (x *ValidateProductInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateProductInventoryRequest.ProtoReflect.Descriptor instead.
+func (*ValidateProductInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ValidateProductInventoryRequest) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ValidateProductInventoryRequest) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ValidateProductInventoryRequest) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type ValidateProductInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateProductInventoryResponse) Reset() {
+	*x = ValidateProductInventoryResponse{}
+	mi := &file_ecommerce_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateProductInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateProductInventoryResponse) ProtoMessage() {}
+
+func (x *ValidateProductInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateProductInventoryResponse.ProtoReflect.Descriptor instead.
+func (*ValidateProductInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ValidateProductInventoryResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type PlaceOrderRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// both ignored, the user and their email are taken from the authenticated request
+	UserId    uint64 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserEmail string `protobuf:"bytes,3,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	Address   string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Country   string `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	// retries with the same key return the original checkout instead of placing a new order
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_ecommerce_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PlaceOrderRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type PlaceOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutUrl   string                 `protobuf:"bytes,1,opt,name=checkout_url,json=checkoutUrl,proto3" json:"checkout_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_ecommerce_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PlaceOrderResponse) GetCheckoutUrl() string {
+	if x != nil {
+		return x.CheckoutUrl
+	}
+	return ""
+}
+
+type OrderItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OrderId   uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId uint64                 `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  uint64                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// deprecated, use price_minor and currency
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Price            float32 `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
+	ProductName      string  `protobuf:"bytes,5,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ProductImage     string  `protobuf:"bytes,6,opt,name=product_image,json=productImage,proto3" json:"product_image,omitempty"`
+	CreatedAt        string  `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        string  `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RefundedQuantity uint64  `protobuf:"varint,9,opt,name=refunded_quantity,json=refundedQuantity,proto3" json:"refunded_quantity,omitempty"`
+	ShippedQuantity  uint64  `protobuf:"varint,10,opt,name=shipped_quantity,json=shippedQuantity,proto3" json:"shipped_quantity,omitempty"`
+	ReturnedQuantity uint64  `protobuf:"varint,11,opt,name=returned_quantity,json=returnedQuantity,proto3" json:"returned_quantity,omitempty"`
+	PriceMinor       int64   `protobuf:"varint,12,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Currency         string  `protobuf:"bytes,13,opt,name=currency,proto3" json:"currency,omitempty"`
+	VariantId        uint64  `protobuf:"varint,14,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Sku              string  `protobuf:"bytes,15,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_ecommerce_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *OrderItem) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *OrderItem) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *OrderItem) GetProductImage() string {
+	if x != nil {
+		return x.ProductImage
+	}
+	return ""
+}
+
+func (x *OrderItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OrderItem) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *OrderItem) GetRefundedQuantity() uint64 {
+	if x != nil {
+		return x.RefundedQuantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetShippedQuantity() uint64 {
+	if x != nil {
+		return x.ShippedQuantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetReturnedQuantity() uint64 {
+	if x != nil {
+		return x.ReturnedQuantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *OrderItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderItem) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+type Order struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// deprecated, use total_minor and currency
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Total             float32      `protobuf:"fixed32,3,opt,name=total,proto3" json:"total,omitempty"`
+	Status            string       `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionId     string       `protobuf:"bytes,5,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	CheckoutSessionId string       `protobuf:"bytes,6,opt,name=checkout_session_id,json=checkoutSessionId,proto3" json:"checkout_session_id,omitempty"`
+	PaymentStatus     string       `protobuf:"bytes,7,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
+	OrderItems        []*OrderItem `protobuf:"bytes,8,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
+	Address           string       `protobuf:"bytes,9,opt,name=address,proto3" json:"address,omitempty"`
+	CreatedAt         string       `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string       `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// set on merchant sub-orders, 0 on the buyer's order
+	ParentId   uint64   `protobuf:"varint,12,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	MerchantId uint64   `protobuf:"varint,13,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	SubOrders  []*Order `protobuf:"bytes,14,rep,name=sub_orders,json=subOrders,proto3" json:"sub_orders,omitempty"`
+	// shipments are attached to the merchant sub-orders
+	Shipments     []*Shipment `protobuf:"bytes,15,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	TotalMinor    int64       `protobuf:"varint,16,opt,name=total_minor,json=totalMinor,proto3" json:"total_minor,omitempty"`
+	Currency      string      `protobuf:"bytes,17,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_ecommerce_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Order) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Order) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *Order) GetTotal() float32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *Order) GetCheckoutSessionId() string {
+	if x != nil {
+		return x.CheckoutSessionId
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentStatus() string {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return ""
+}
+
+func (x *Order) GetOrderItems() []*OrderItem {
+	if x != nil {
+		return x.OrderItems
+	}
+	return nil
+}
+
+func (x *Order) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetParentId() uint64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Order) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *Order) GetSubOrders() []*Order {
+	if x != nil {
+		return x.SubOrders
+	}
+	return nil
+}
+
+func (x *Order) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+func (x *Order) GetTotalMinor() int64 {
+	if x != nil {
+		return x.TotalMinor
+	}
+	return 0
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_ecommerce_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetOrderRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetOrdersByUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersByUserRequest) Reset() {
+	*x = GetOrdersByUserRequest{}
+	mi := &file_ecommerce_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersByUserRequest) ProtoMessage() {}
+
+func (x *GetOrdersByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersByUserRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersByUserRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetOrdersByUserRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetOrdersByMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    uint64                 `protobuf:"varint,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersByMerchantRequest) Reset() {
+	*x = GetOrdersByMerchantRequest{}
+	mi := &file_ecommerce_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersByMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersByMerchantRequest) ProtoMessage() {}
+
+func (x *GetOrdersByMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersByMerchantRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersByMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetOrdersByMerchantRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+type GetOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersResponse) Reset() {
+	*x = GetOrdersResponse{}
+	mi := &file_ecommerce_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersResponse) ProtoMessage() {}
+
+func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cursor uint64                 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  uint64                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// ignored, the user is the one the request is authenticated as
+	UserId        uint64 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	PaymentStatus string `protobuf:"bytes,5,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_ecommerce_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListOrdersRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetPaymentStatus() string {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return ""
+}
+
+type ListMerchantOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cursor uint64                 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  uint64                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId    uint64 `protobuf:"varint,3,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	PaymentStatus string `protobuf:"bytes,5,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMerchantOrdersRequest) Reset() {
+	*x = ListMerchantOrdersRequest{}
+	mi := &file_ecommerce_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantOrdersRequest) ProtoMessage() {}
+
+func (x *ListMerchantOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListMerchantOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListMerchantOrdersRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListMerchantOrdersRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMerchantOrdersRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *ListMerchantOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListMerchantOrdersRequest) GetPaymentStatus() string {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Cursor        uint64                 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Total         uint64                 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_ecommerce_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListOrdersResponse) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type RefundItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     uint64                 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundItem) Reset() {
+	*x = RefundItem{}
+	mi := &file_ecommerce_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundItem) ProtoMessage() {}
+
+func (x *RefundItem) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundItem.ProtoReflect.Descriptor instead.
+func (*RefundItem) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RefundItem) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *RefundItem) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RefundItem) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type Refund struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        uint64                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	StripeRefundId string                 `protobuf:"bytes,3,opt,name=stripe_refund_id,json=stripeRefundId,proto3" json:"stripe_refund_id,omitempty"`
+	// deprecated, use amount_minor and currency
+	//
+	// Deprecated: Marked as deprecated in ecommerce.proto.
+	Amount        float32       `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string        `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status        string        `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Restocked     bool          `protobuf:"varint,7,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	Items         []*RefundItem `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     string        `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AmountMinor   int64         `protobuf:"varint,10,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
+	Currency      string        `protobuf:"bytes,11,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_ecommerce_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Refund) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Refund) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Refund) GetStripeRefundId() string {
+	if x != nil {
+		return x.StripeRefundId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in ecommerce.proto.
+func (x *Refund) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Refund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Refund) GetRestocked() bool {
+	if x != nil {
+		return x.Restocked
+	}
+	return false
+}
+
+func (x *Refund) GetItems() []*RefundItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Refund) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Refund) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+func (x *Refund) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type RefundOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// leave empty to refund everything that has not been refunded yet
+	Items         []*RefundItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Restock       bool          `protobuf:"varint,3,opt,name=restock,proto3" json:"restock,omitempty"`
+	Reason        string        `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_ecommerce_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RefundOrderRequest) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RefundOrderRequest) GetItems() []*RefundItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RefundOrderRequest) GetRestock() bool {
+	if x != nil {
+		return x.Restock
+	}
+	return false
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RefundOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_ecommerce_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RefundOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *RefundOrderResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+type Merchant struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StripeAccountId string                 `protobuf:"bytes,2,opt,name=stripe_account_id,json=stripeAccountId,proto3" json:"stripe_account_id,omitempty"`
+	// platform commission in basis points
+	CommissionBps uint32 `protobuf:"varint,3,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Merchant) Reset() {
+	*x = Merchant{}
+	mi := &file_ecommerce_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Merchant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Merchant) ProtoMessage() {}
+
+func (x *Merchant) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Merchant.ProtoReflect.Descriptor instead.
+func (*Merchant) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Merchant) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Merchant) GetStripeAccountId() string {
+	if x != nil {
+		return x.StripeAccountId
+	}
+	return ""
+}
+
+func (x *Merchant) GetCommissionBps() uint32 {
+	if x != nil {
+		return x.CommissionBps
+	}
+	return 0
+}
+
+type OnboardMerchantRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId    uint64 `protobuf:"varint,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Email         string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnboardMerchantRequest) Reset() {
+	*x = OnboardMerchantRequest{}
+	mi := &file_ecommerce_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnboardMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnboardMerchantRequest) ProtoMessage() {}
+
+func (x *OnboardMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnboardMerchantRequest.ProtoReflect.Descriptor instead.
+func (*OnboardMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *OnboardMerchantRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *OnboardMerchantRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type OnboardMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	OnboardingUrl string                 `protobuf:"bytes,2,opt,name=onboarding_url,json=onboardingUrl,proto3" json:"onboarding_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnboardMerchantResponse) Reset() {
+	*x = OnboardMerchantResponse{}
+	mi := &file_ecommerce_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnboardMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnboardMerchantResponse) ProtoMessage() {}
+
+func (x *OnboardMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnboardMerchantResponse.ProtoReflect.Descriptor instead.
+func (*OnboardMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *OnboardMerchantResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+func (x *OnboardMerchantResponse) GetOnboardingUrl() string {
+	if x != nil {
+		return x.OnboardingUrl
+	}
+	return ""
+}
+
+type SetMerchantCommissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    uint64                 `protobuf:"varint,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	CommissionBps uint32                 `protobuf:"varint,2,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMerchantCommissionRequest) Reset() {
+	*x = SetMerchantCommissionRequest{}
+	mi := &file_ecommerce_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMerchantCommissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMerchantCommissionRequest) ProtoMessage() {}
+
+func (x *SetMerchantCommissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMerchantCommissionRequest.ProtoReflect.Descriptor instead.
+func (*SetMerchantCommissionRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SetMerchantCommissionRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *SetMerchantCommissionRequest) GetCommissionBps() uint32 {
+	if x != nil {
+		return x.CommissionBps
+	}
+	return 0
+}
+
+type LedgerEntry struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId    uint64                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SubOrderId uint64                 `protobuf:"varint,3,opt,name=sub_order_id,json=subOrderId,proto3" json:"sub_order_id,omitempty"`
+	MerchantId uint64                 `protobuf:"varint,4,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Type       string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// in cents
+	Amount         int64  `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency       string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	StripeObjectId string `protobuf:"bytes,8,opt,name=stripe_object_id,json=stripeObjectId,proto3" json:"stripe_object_id,omitempty"`
+	CreatedAt      string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_ecommerce_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *LedgerEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetSubOrderId() uint64 {
+	if x != nil {
+		return x.SubOrderId
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetStripeObjectId() string {
+	if x != nil {
+		return x.StripeObjectId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetOrderLedgerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderLedgerRequest) Reset() {
+	*x = GetOrderLedgerRequest{}
+	mi := &file_ecommerce_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderLedgerRequest) ProtoMessage() {}
+
+func (x *GetOrderLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderLedgerRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetOrderLedgerRequest) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type GetOrderLedgerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderLedgerResponse) Reset() {
+	*x = GetOrderLedgerResponse{}
+	mi := &file_ecommerce_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderLedgerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderLedgerResponse) ProtoMessage() {}
+
+func (x *GetOrderLedgerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderLedgerResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderLedgerResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetOrderLedgerResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type StockMovement struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId uint64                 `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// 0 for products without variants
+	VariantId uint64 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// order_placed, order_cancelled, manual_adjustment, return_restock, refund_restock, refund_failed or import
+	Type  string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Delta int64  `protobuf:"varint,5,opt,name=delta,proto3" json:"delta,omitempty"`
+	// inventory after the movement
+	Quantity uint64 `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// user:<id>, or system for background jobs and payment webhooks
+	Actor string `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	// order, return or refund ID depending on the type
+	ReferenceId   uint64 `protobuf:"varint,8,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	CreatedAt     string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_ecommerce_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *StockMovement) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockMovement) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockMovement) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StockMovement) GetReferenceId() uint64 {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetStockHistoryRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId uint64 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	// only the movements of this variant, 0 for all movements of the product
+	VariantId     uint64 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Cursor        uint64 `protobuf:"varint,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         uint64 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockHistoryRequest) Reset() {
+	*x = GetStockHistoryRequest{}
+	mi := &file_ecommerce_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockHistoryRequest) ProtoMessage() {}
+
+func (x *GetStockHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetStockHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetStockHistoryRequest) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *GetStockHistoryRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *GetStockHistoryRequest) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *GetStockHistoryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetStockHistoryRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetStockHistoryRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetStockHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	Cursor        uint64                 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockHistoryResponse) Reset() {
+	*x = GetStockHistoryResponse{}
+	mi := &file_ecommerce_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockHistoryResponse) ProtoMessage() {}
+
+func (x *GetStockHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetStockHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GetStockHistoryResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *GetStockHistoryResponse) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type ShipmentItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     uint64                 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentItem) Reset() {
+	*x = ShipmentItem{}
+	mi := &file_ecommerce_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentItem) ProtoMessage() {}
+
+func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
+func (*ShipmentItem) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ShipmentItem) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ShipmentItem) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ShipmentItem) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type Shipment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the merchant sub-order being shipped
+	OrderId        uint64          `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MerchantId     uint64          `protobuf:"varint,3,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Carrier        string          `protobuf:"bytes,4,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string          `protobuf:"bytes,5,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Status         string          `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Items          []*ShipmentItem `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	ShippedAt      string          `protobuf:"bytes,8,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	DeliveredAt    string          `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt      string          `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_ecommerce_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Shipment) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Shipment) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Shipment) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Shipment) GetShippedAt() string {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return ""
+}
+
+func (x *Shipment) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *Shipment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateShipmentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId     uint64 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Carrier        string `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string `protobuf:"bytes,4,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	// leave empty to ship everything that has not been shipped yet
+	Items         []*ShipmentItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_ecommerce_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() uint64 {
 	if x != nil {
 		return x.OrderId
 	}
 	return 0
 }
 
-func (x *OrderItem) GetProductId() uint64 {
+func (x *CreateShipmentRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type UpdateShipmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId     uint64 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Carrier        string `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string `protobuf:"bytes,4,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	// shipped or delivered, leave empty to keep the current status
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentRequest) Reset() {
+	*x = UpdateShipmentRequest{}
+	mi := &file_ecommerce_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentRequest) ProtoMessage() {}
+
+func (x *UpdateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UpdateShipmentRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateShipmentRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *UpdateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *UpdateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *UpdateShipmentRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ReturnItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     uint64                 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_ecommerce_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ReturnItem) GetProductId() uint64 {
 	if x != nil {
 		return x.ProductId
 	}
 	return 0
 }
 
-func (x *OrderItem) GetQuantity() uint64 {
+func (x *ReturnItem) GetQuantity() uint64 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-func (x *OrderItem) GetPrice() float32 {
+func (x *ReturnItem) GetVariantId() uint64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type ReturnStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	ActorId       uint64                 `protobuf:"varint,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnStatusChange) Reset() {
+	*x = ReturnStatusChange{}
+	mi := &file_ecommerce_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnStatusChange) ProtoMessage() {}
+
+func (x *ReturnStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnStatusChange.ProtoReflect.Descriptor instead.
+func (*ReturnStatusChange) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReturnStatusChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReturnStatusChange) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReturnStatusChange) GetActorId() uint64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *ReturnStatusChange) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Return struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId    uint64                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SubOrderId uint64                 `protobuf:"varint,3,opt,name=sub_order_id,json=subOrderId,proto3" json:"sub_order_id,omitempty"`
+	UserId     uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MerchantId uint64                 `protobuf:"varint,5,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Reason     string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status     string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Items      []*ReturnItem          `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	Restocked  bool                   `protobuf:"varint,9,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	// set when the buyer was refunded for the return
+	RefundId      uint64                `protobuf:"varint,10,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	History       []*ReturnStatusChange `protobuf:"bytes,11,rep,name=history,proto3" json:"history,omitempty"`
+	CreatedAt     string                `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_ecommerce_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *Return) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Return) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Return) GetSubOrderId() uint64 {
+	if x != nil {
+		return x.SubOrderId
+	}
+	return 0
+}
+
+func (x *Return) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Return) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *Return) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Return) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Return) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Return) GetRestocked() bool {
 	if x != nil {
-		return x.Price
+		return x.Restocked
 	}
-	return 0
+	return false
 }
 
-func (x *OrderItem) GetProductName() string {
+func (x *Return) GetRefundId() uint64 {
 	if x != nil {
-		return x.ProductName
+		return x.RefundId
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderItem) GetProductImage() string {
+func (x *Return) GetHistory() []*ReturnStatusChange {
 	if x != nil {
-		return x.ProductImage
+		return x.History
 	}
-	return ""
+	return nil
 }
 
-func (x *OrderItem) GetCreatedAt() string {
+func (x *Return) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *OrderItem) GetUpdatedAt() string {
+func (x *Return) GetUpdatedAt() string {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return ""
 }
 
-type Order struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId            uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Total             float32                `protobuf:"fixed32,3,opt,name=total,proto3" json:"total,omitempty"`
-	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	TransactionId     string                 `protobuf:"bytes,5,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	CheckoutSessionId string                 `protobuf:"bytes,6,opt,name=checkout_session_id,json=checkoutSessionId,proto3" json:"checkout_session_id,omitempty"`
-	PaymentStatus     string                 `protobuf:"bytes,7,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
-	OrderItems        []*OrderItem           `protobuf:"bytes,8,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
-	Address           string                 `protobuf:"bytes,9,opt,name=address,proto3" json:"address,omitempty"`
-	CreatedAt         string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         string                 `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type RequestReturnRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// ignored, the user is the one the request is authenticated as
+	UserId uint64 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// all items must be sold by the same merchant
+	Items         []*ReturnItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Reason        string        `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_ecommerce_proto_msgTypes[22]
+func (x *RequestReturnRequest) Reset() {
+	*x = RequestReturnRequest{}
+	mi := &file_ecommerce_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *RequestReturnRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*RequestReturnRequest) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[22]
+func (x *RequestReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,110 +5582,141 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use RequestReturnRequest.ProtoReflect.Descriptor instead.
+func (*RequestReturnRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *Order) GetId() uint64 {
+func (x *RequestReturnRequest) GetOrderId() uint64 {
 	if x != nil {
-		return x.Id
+		return x.OrderId
 	}
 	return 0
 }
 
-func (x *Order) GetUserId() uint64 {
+func (x *RequestReturnRequest) GetUserId() uint64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *Order) GetTotal() float32 {
+func (x *RequestReturnRequest) GetItems() []*ReturnItem {
 	if x != nil {
-		return x.Total
+		return x.Items
 	}
-	return 0
+	return nil
 }
 
-func (x *Order) GetStatus() string {
+func (x *RequestReturnRequest) GetReason() string {
 	if x != nil {
-		return x.Status
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *Order) GetTransactionId() string {
-	if x != nil {
-		return x.TransactionId
-	}
-	return ""
+type ApproveReturnRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId    uint64 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Restock       bool   `protobuf:"varint,3,opt,name=restock,proto3" json:"restock,omitempty"`
+	Refund        bool   `protobuf:"varint,4,opt,name=refund,proto3" json:"refund,omitempty"`
+	Note          string `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) GetCheckoutSessionId() string {
+func (x *ApproveReturnRequest) Reset() {
+	*x = ApproveReturnRequest{}
+	mi := &file_ecommerce_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReturnRequest) ProtoMessage() {}
+
+func (x *ApproveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[75]
 	if x != nil {
-		return x.CheckoutSessionId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Order) GetPaymentStatus() string {
+// Deprecated: Use ApproveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ApproveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ApproveReturnRequest) GetId() uint64 {
 	if x != nil {
-		return x.PaymentStatus
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *Order) GetOrderItems() []*OrderItem {
+func (x *ApproveReturnRequest) GetMerchantId() uint64 {
 	if x != nil {
-		return x.OrderItems
+		return x.MerchantId
 	}
-	return nil
+	return 0
 }
 
-func (x *Order) GetAddress() string {
+func (x *ApproveReturnRequest) GetRestock() bool {
 	if x != nil {
-		return x.Address
+		return x.Restock
 	}
-	return ""
+	return false
 }
 
-func (x *Order) GetCreatedAt() string {
+func (x *ApproveReturnRequest) GetRefund() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.Refund
 	}
-	return ""
+	return false
 }
 
-func (x *Order) GetUpdatedAt() string {
+func (x *ApproveReturnRequest) GetNote() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Note
 	}
 	return ""
 }
 
-type GetOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+type RejectReturnRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ignored, the merchant is the one the request is authenticated as
+	MerchantId    uint64 `protobuf:"varint,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Note          string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_ecommerce_proto_msgTypes[23]
+func (x *RejectReturnRequest) Reset() {
+	*x = RejectReturnRequest{}
+	mi := &file_ecommerce_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *RejectReturnRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*RejectReturnRequest) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[23]
+func (x *RejectReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1498,40 +5727,54 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RejectReturnRequest.ProtoReflect.Descriptor instead.
+func (*RejectReturnRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetOrderRequest) GetId() uint64 {
+func (x *RejectReturnRequest) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type GetOrdersByUserRequest struct {
+func (x *RejectReturnRequest) GetMerchantId() uint64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *RejectReturnRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type GetReturnRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersByUserRequest) Reset() {
-	*x = GetOrdersByUserRequest{}
-	mi := &file_ecommerce_proto_msgTypes[24]
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	mi := &file_ecommerce_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersByUserRequest) String() string {
+func (x *GetReturnRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersByUserRequest) ProtoMessage() {}
+func (*GetReturnRequest) ProtoMessage() {}
 
-func (x *GetOrdersByUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[24]
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1542,40 +5785,40 @@ func (x *GetOrdersByUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersByUserRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersByUserRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *GetOrdersByUserRequest) GetUserId() uint64 {
+func (x *GetReturnRequest) GetId() uint64 {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return 0
 }
 
-type GetOrdersByMerchantRequest struct {
+type ListOrderReturnsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MerchantId    uint64                 `protobuf:"varint,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersByMerchantRequest) Reset() {
-	*x = GetOrdersByMerchantRequest{}
-	mi := &file_ecommerce_proto_msgTypes[25]
+func (x *ListOrderReturnsRequest) Reset() {
+	*x = ListOrderReturnsRequest{}
+	mi := &file_ecommerce_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersByMerchantRequest) String() string {
+func (x *ListOrderReturnsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersByMerchantRequest) ProtoMessage() {}
+func (*ListOrderReturnsRequest) ProtoMessage() {}
 
-func (x *GetOrdersByMerchantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[25]
+func (x *ListOrderReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1586,40 +5829,40 @@ func (x *GetOrdersByMerchantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersByMerchantRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersByMerchantRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ListOrderReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *GetOrdersByMerchantRequest) GetMerchantId() uint64 {
+func (x *ListOrderReturnsRequest) GetOrderId() uint64 {
 	if x != nil {
-		return x.MerchantId
+		return x.OrderId
 	}
 	return 0
 }
 
-type GetOrdersResponse struct {
+type ListOrderReturnsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Returns       []*Return              `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersResponse) Reset() {
-	*x = GetOrdersResponse{}
-	mi := &file_ecommerce_proto_msgTypes[26]
+func (x *ListOrderReturnsResponse) Reset() {
+	*x = ListOrderReturnsResponse{}
+	mi := &file_ecommerce_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersResponse) String() string {
+func (x *ListOrderReturnsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersResponse) ProtoMessage() {}
+func (*ListOrderReturnsResponse) ProtoMessage() {}
 
-func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[26]
+func (x *ListOrderReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1630,14 +5873,14 @@ func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
-func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ListOrderReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_ecommerce_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *GetOrdersResponse) GetOrders() []*Order {
+func (x *ListOrderReturnsResponse) GetReturns() []*Return {
 	if x != nil {
-		return x.Orders
+		return x.Returns
 	}
 	return nil
 }
@@ -1652,7 +5895,7 @@ type UpdateOrderStatusRequest struct {
 
 func (x *UpdateOrderStatusRequest) Reset() {
 	*x = UpdateOrderStatusRequest{}
-	mi := &file_ecommerce_proto_msgTypes[27]
+	mi := &file_ecommerce_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1664,7 +5907,7 @@ func (x *UpdateOrderStatusRequest) String() string {
 func (*UpdateOrderStatusRequest) ProtoMessage() {}
 
 func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[27]
+	mi := &file_ecommerce_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1677,7 +5920,7 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{27}
+	return file_ecommerce_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *UpdateOrderStatusRequest) GetId() uint64 {
@@ -1695,15 +5938,17 @@ func (x *UpdateOrderStatusRequest) GetStatus() string {
 }
 
 type CancelOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ignored, the user is the one the request is authenticated as
+	UserId        uint64 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CancelOrderRequest) Reset() {
 	*x = CancelOrderRequest{}
-	mi := &file_ecommerce_proto_msgTypes[28]
+	mi := &file_ecommerce_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1715,7 +5960,7 @@ func (x *CancelOrderRequest) String() string {
 func (*CancelOrderRequest) ProtoMessage() {}
 
 func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[28]
+	mi := &file_ecommerce_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1728,7 +5973,7 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
 func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{28}
+	return file_ecommerce_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *CancelOrderRequest) GetId() uint64 {
@@ -1738,6 +5983,13 @@ func (x *CancelOrderRequest) GetId() uint64 {
 	return 0
 }
 
+func (x *CancelOrderRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type UpdatePaymentStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
@@ -1748,7 +6000,7 @@ type UpdatePaymentStatusRequest struct {
 
 func (x *UpdatePaymentStatusRequest) Reset() {
 	*x = UpdatePaymentStatusRequest{}
-	mi := &file_ecommerce_proto_msgTypes[29]
+	mi := &file_ecommerce_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1760,7 +6012,7 @@ func (x *UpdatePaymentStatusRequest) String() string {
 func (*UpdatePaymentStatusRequest) ProtoMessage() {}
 
 func (x *UpdatePaymentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_proto_msgTypes[29]
+	mi := &file_ecommerce_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1773,7 +6025,7 @@ func (x *UpdatePaymentStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePaymentStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePaymentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_ecommerce_proto_rawDescGZIP(), []int{29}
+	return file_ecommerce_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *UpdatePaymentStatusRequest) GetEvent() string {
@@ -1794,10 +6046,12 @@ var File_ecommerce_proto protoreflect.FileDescriptor
 
 const file_ecommerce_proto_rawDesc = "" +
 	"\n" +
-	"\x0fecommerce.proto\x12\tecommerce\"6\n" +
+	"\x0fecommerce.proto\x12\tecommerce\"U\n" +
 	"\bCartItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x04R\bquantity\"X\n" +
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\"X\n" +
 	"\x0eAddItemRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12'\n" +
@@ -1821,64 +6075,269 @@ const file_ecommerce_proto_rawDesc = "" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x04R\x02id\x12\x1a\n" +
 	"\bquantity\x18\x03 \x01(\x04R\bquantity\"\a\n" +
-	"\x05Empty\"\x90\x02\n" +
+	"\x05Empty\"\x80\x06\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x02R\x05price\x12\x1c\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x02B\x02\x18\x01R\x05price\x12\x1c\n" +
 	"\tinventory\x18\x04 \x01(\x04R\tinventory\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x16\n" +
 	"\x06images\x18\x06 \x03(\tR\x06images\x12&\n" +
 	"\x0fstripe_price_id\x18\a \x01(\tR\rstripePriceId\x12*\n" +
 	"\x11stripe_product_id\x18\b \x01(\tR\x0fstripeProductId\x12\x1f\n" +
 	"\vmerchant_id\x18\t \x01(\x04R\n" +
-	"merchantId\"g\n" +
+	"merchantId\x12\x1f\n" +
+	"\vprice_minor\x18\v \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\x12/\n" +
+	"\x06prices\x18\r \x03(\v2\x17.ecommerce.ProductPriceR\x06prices\x122\n" +
+	"\aoptions\x18\x0e \x03(\v2\x18.ecommerce.ProductOptionR\aoptions\x125\n" +
+	"\bvariants\x18\x0f \x03(\v2\x19.ecommerce.ProductVariantR\bvariants\x123\n" +
+	"\n" +
+	"categories\x18\x10 \x03(\v2\x13.ecommerce.CategoryR\n" +
+	"categories\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\tR\tcreatedAt\x12H\n" +
+	"\x0especifications\x18\x12 \x01(\v2 .ecommerce.ProductSpecificationsR\x0especifications\x12\x16\n" +
+	"\x06status\x18\x13 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"publish_at\x18\x14 \x01(\tR\tpublishAt\x12\x1d\n" +
+	"\n" +
+	"is_deleted\x18\n" +
+	" \x01(\bR\tisDeleted\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x15 \x01(\tR\tdeletedAt\"\xfe\x01\n" +
+	"\x15ProductSpecifications\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x1a\n" +
+	"\bmaterial\x18\x02 \x01(\tR\bmaterial\x12!\n" +
+	"\fweight_grams\x18\x03 \x01(\x04R\vweightGrams\x12\x1b\n" +
+	"\tlength_mm\x18\x04 \x01(\x04R\blengthMm\x12\x19\n" +
+	"\bwidth_mm\x18\x05 \x01(\x04R\awidthMm\x12\x1b\n" +
+	"\theight_mm\x18\x06 \x01(\x04R\bheightMm\x12;\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2\x1b.ecommerce.ProductAttributeR\n" +
+	"attributes\"\x9d\x01\n" +
+	"\x10ProductAttribute\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\fstring_value\x18\x03 \x01(\tR\vstringValue\x12!\n" +
+	"\fnumber_value\x18\x04 \x01(\x01R\vnumberValue\x12\x1d\n" +
+	"\n" +
+	"bool_value\x18\x05 \x01(\bR\tboolValue\"_\n" +
+	"\x0fAttributeFilter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x04 \x01(\x01R\x03max\"s\n" +
+	"\fProductPrice\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vprice_minor\x18\x02 \x01(\x03R\n" +
+	"priceMinor\x12&\n" +
+	"\x0fstripe_price_id\x18\x03 \x01(\tR\rstripePriceId\";\n" +
+	"\rProductOption\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"\xf5\x01\n" +
+	"\x0eProductVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x04R\tproductId\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12#\n" +
+	"\roption_values\x18\x04 \x03(\tR\foptionValues\x12\x1f\n" +
+	"\vprice_minor\x18\x05 \x01(\x03R\n" +
+	"priceMinor\x12\x1c\n" +
+	"\tinventory\x18\x06 \x01(\x04R\tinventory\x12\x16\n" +
+	"\x06images\x18\a \x03(\tR\x06images\x12&\n" +
+	"\x0fstripe_price_id\x18\b \x01(\tR\rstripePriceId\"\xe6\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x04R\bparentId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x04 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12%\n" +
+	"\x0ecommission_bps\x18\b \x01(\rR\rcommissionBps\"\xa5\x01\n" +
+	"\x15CreateCategoryRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\x04R\bparentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12%\n" +
+	"\x0ecommission_bps\x18\x05 \x01(\rR\rcommissionBps\"\xb5\x01\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x04R\bparentId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x04 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12%\n" +
+	"\x0ecommission_bps\x18\x06 \x01(\rR\rcommissionBps\"'\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"8\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\"F\n" +
+	"\x15ListCategoriesRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\x04R\bparentId\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"M\n" +
+	"\x16ListCategoriesResponse\x123\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x13.ecommerce.CategoryR\n" +
+	"categories\"\x80\x01\n" +
+	"\x1bSetProductCategoriesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12!\n" +
+	"\fcategory_ids\x18\x03 \x03(\x04R\vcategoryIds\"\x8e\x01\n" +
+	"\x18SetProductOptionsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x122\n" +
+	"\aoptions\x18\x03 \x03(\v2\x18.ecommerce.ProductOptionR\aoptions\"\xd3\x01\n" +
+	"\x1bCreateProductVariantRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12#\n" +
+	"\roption_values\x18\x04 \x03(\tR\foptionValues\x12\x1f\n" +
+	"\vprice_minor\x18\x05 \x01(\x03R\n" +
+	"priceMinor\x12\x1c\n" +
+	"\tinventory\x18\x06 \x01(\x04R\tinventory\"\xc4\x01\n" +
+	"\x1bUpdateProductVariantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12#\n" +
+	"\roption_values\x18\x04 \x03(\tR\foptionValues\x12\x1f\n" +
+	"\vprice_minor\x18\x05 \x01(\x03R\n" +
+	"priceMinor\x12\x1c\n" +
+	"\tinventory\x18\x06 \x01(\x04R\tinventory\"N\n" +
+	"\x1bDeleteProductVariantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\"\x86\x01\n" +
 	"\x1aUpdateProductImagesRequest\x12\x1d\n" +
 	"\n" +
 	"image_data\x18\x01 \x01(\fR\timageData\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x0e\n" +
-	"\x02id\x18\x03 \x01(\x04R\x02id\"D\n" +
+	"\x02id\x18\x03 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\x04R\tvariantId\"D\n" +
 	"\x1bUpdateProductImagesResponse\x12%\n" +
-	"\x0euploaded_files\x18\x01 \x03(\tR\ruploadedFiles\"\xa1\x01\n" +
+	"\x0euploaded_files\x18\x01 \x03(\tR\ruploadedFiles\"\xb7\x03\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05price\x18\x02 \x01(\x02R\x05price\x12\x1c\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\x05price\x18\x02 \x01(\x02B\x02\x18\x01R\x05price\x12\x1c\n" +
 	"\tinventory\x18\x03 \x01(\x04R\tinventory\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
 	"\vmerchant_id\x18\x05 \x01(\x04R\n" +
-	"merchantId\"\x9d\x02\n" +
+	"merchantId\x12\x1f\n" +
+	"\vprice_minor\x18\x06 \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12/\n" +
+	"\x06prices\x18\b \x03(\v2\x17.ecommerce.ProductPriceR\x06prices\x12!\n" +
+	"\fcategory_ids\x18\t \x03(\x04R\vcategoryIds\x12H\n" +
+	"\x0especifications\x18\n" +
+	" \x01(\v2 .ecommerce.ProductSpecificationsR\x0especifications\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"publish_at\x18\f \x01(\tR\tpublishAt\"\x90\x04\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x02R\x05price\x12\x1c\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x02B\x02\x18\x01R\x05price\x12\x1c\n" +
 	"\tinventory\x18\x04 \x01(\x04R\tinventory\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x16\n" +
 	"\x06images\x18\x06 \x03(\tR\x06images\x12&\n" +
 	"\x0fstripe_price_id\x18\a \x01(\tR\rstripePriceId\x12*\n" +
 	"\x11stripe_product_id\x18\b \x01(\tR\x0fstripeProductId\x12\x1f\n" +
 	"\vmerchant_id\x18\t \x01(\x04R\n" +
-	"merchantId\"G\n" +
+	"merchantId\x12\x1f\n" +
+	"\vprice_minor\x18\n" +
+	" \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\v \x01(\tR\bcurrency\x12/\n" +
+	"\x06prices\x18\f \x03(\v2\x17.ecommerce.ProductPriceR\x06prices\x12H\n" +
+	"\x0especifications\x18\r \x01(\v2 .ecommerce.ProductSpecificationsR\x0especifications\x12\x16\n" +
+	"\x06status\x18\x0e \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"publish_at\x18\x0f \x01(\tR\tpublishAt\"G\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
 	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
-	"merchantId\"t\n" +
+	"merchantId\"\xca\x01\n" +
 	"\x14ListProductsResponse\x12.\n" +
-	"\bproducts\x18\x01 \x03(\v2\x12.ecommerce.ProductR\bproducts\x12\x16\n" +
-	"\x06cursor\x18\x02 \x01(\x04R\x06cursor\x12\x14\n" +
-	"\x05total\x18\x03 \x01(\x04R\x05total\"d\n" +
-	"\x13ListProductsRequest\x12\x16\n" +
-	"\x06cursor\x18\x01 \x01(\x04R\x06cursor\x12\x14\n" +
+	"\bproducts\x18\x01 \x03(\v2\x12.ecommerce.ProductR\bproducts\x12\x1a\n" +
+	"\x06cursor\x18\x02 \x01(\x04B\x02\x18\x01R\x06cursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x04R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12(\n" +
+	"\x06facets\x18\x05 \x03(\v2\x10.ecommerce.FacetR\x06facets\"J\n" +
+	"\x05Facet\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x06values\x18\x02 \x03(\v2\x15.ecommerce.FacetValueR\x06values\"N\n" +
+	"\n" +
+	"FacetValue\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\"\xcb\x03\n" +
+	"\x13ListProductsRequest\x12\x1a\n" +
+	"\x06cursor\x18\x01 \x01(\x04B\x02\x18\x01R\x06cursor\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x04R\x05limit\x12\x1f\n" +
 	"\vmerchant_id\x18\x03 \x01(\x04R\n" +
+	"merchantId\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\x04R\n" +
+	"categoryId\x12&\n" +
+	"\x0fmin_price_minor\x18\x05 \x01(\x03R\rminPriceMinor\x12&\n" +
+	"\x0fmax_price_minor\x18\x06 \x01(\x03R\rmaxPriceMinor\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x19\n" +
+	"\bin_stock\x18\b \x01(\bR\ainStock\x12#\n" +
+	"\rcreated_after\x18\t \x01(\tR\fcreatedAfter\x12\x12\n" +
+	"\x04sort\x18\n" +
+	" \x01(\tR\x04sort\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x12%\n" +
+	"\x0einclude_facets\x18\f \x01(\bR\rincludeFacets\x12:\n" +
+	"\n" +
+	"attributes\x18\r \x03(\v2\x1a.ecommerce.AttributeFilterR\n" +
+	"attributes\"\x83\x02\n" +
+	"\x15SearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\x06cursor\x18\x02 \x01(\x04B\x02\x18\x01R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x04R\x05limit\x12\x1f\n" +
+	"\vmerchant_id\x18\x04 \x01(\x04R\n" +
+	"merchantId\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\x04R\n" +
+	"categoryId\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12%\n" +
+	"\x0einclude_facets\x18\a \x01(\bR\rincludeFacets\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\"\xcc\x01\n" +
+	"\x1bListMerchantProductsRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\x04R\n" +
+	"merchantId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x04R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x1a\n" +
+	"\bstatuses\x18\x05 \x03(\tR\bstatuses\x12'\n" +
+	"\x0finclude_deleted\x18\x06 \x01(\bR\x0eincludeDeleted\"H\n" +
+	"\x15RestoreProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
 	"merchantId\"#\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\"\\\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"{\n" +
 	"\x1fValidateProductInventoryRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x04R\bquantity\"8\n" +
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\"8\n" +
 	" ValidateProductInventoryResponse\x12\x14\n" +
-	"\x05valid\x18\x01 \x01(\bR\x05valid\"\x9e\x01\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"\xc7\x01\n" +
 	"\x11PlaceOrderRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
@@ -1886,25 +6345,36 @@ const file_ecommerce_proto_rawDesc = "" +
 	"\n" +
 	"user_email\x18\x03 \x01(\tR\tuserEmail\x12\x18\n" +
 	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x18\n" +
-	"\acountry\x18\x05 \x01(\tR\acountry\"7\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12'\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\"7\n" +
 	"\x12PlaceOrderResponse\x12!\n" +
-	"\fcheckout_url\x18\x01 \x01(\tR\vcheckoutUrl\"\xfd\x01\n" +
+	"\fcheckout_url\x18\x01 \x01(\tR\vcheckoutUrl\"\xf4\x03\n" +
 	"\tOrderItem\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x02 \x01(\x04R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x04R\bquantity\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\x12!\n" +
+	"\bquantity\x18\x03 \x01(\x04R\bquantity\x12\x18\n" +
+	"\x05price\x18\x04 \x01(\x02B\x02\x18\x01R\x05price\x12!\n" +
 	"\fproduct_name\x18\x05 \x01(\tR\vproductName\x12#\n" +
 	"\rproduct_image\x18\x06 \x01(\tR\fproductImage\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"\xeb\x02\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12+\n" +
+	"\x11refunded_quantity\x18\t \x01(\x04R\x10refundedQuantity\x12)\n" +
+	"\x10shipped_quantity\x18\n" +
+	" \x01(\x04R\x0fshippedQuantity\x12+\n" +
+	"\x11returned_quantity\x18\v \x01(\x04R\x10returnedQuantity\x12\x1f\n" +
+	"\vprice_minor\x18\f \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\r \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x0e \x01(\x04R\tvariantId\x12\x10\n" +
+	"\x03sku\x18\x0f \x01(\tR\x03sku\"\xce\x04\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x14\n" +
-	"\x05total\x18\x03 \x01(\x02R\x05total\x12\x16\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x18\n" +
+	"\x05total\x18\x03 \x01(\x02B\x02\x18\x01R\x05total\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
 	"\x0etransaction_id\x18\x05 \x01(\tR\rtransactionId\x12.\n" +
 	"\x13checkout_session_id\x18\x06 \x01(\tR\x11checkoutSessionId\x12%\n" +
@@ -1916,7 +6386,16 @@ const file_ecommerce_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\tR\tupdatedAt\"!\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\x12\x1b\n" +
+	"\tparent_id\x18\f \x01(\x04R\bparentId\x12\x1f\n" +
+	"\vmerchant_id\x18\r \x01(\x04R\n" +
+	"merchantId\x12/\n" +
+	"\n" +
+	"sub_orders\x18\x0e \x03(\v2\x10.ecommerce.OrderR\tsubOrders\x121\n" +
+	"\tshipments\x18\x0f \x03(\v2\x13.ecommerce.ShipmentR\tshipments\x12\x1f\n" +
+	"\vtotal_minor\x18\x10 \x01(\x03R\n" +
+	"totalMinor\x12\x1a\n" +
+	"\bcurrency\x18\x11 \x01(\tR\bcurrency\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\"1\n" +
 	"\x16GetOrdersByUserRequest\x12\x17\n" +
@@ -1925,12 +6404,207 @@ const file_ecommerce_proto_rawDesc = "" +
 	"\vmerchant_id\x18\x01 \x01(\x04R\n" +
 	"merchantId\"=\n" +
 	"\x11GetOrdersResponse\x12(\n" +
-	"\x06orders\x18\x01 \x03(\v2\x10.ecommerce.OrderR\x06orders\"B\n" +
+	"\x06orders\x18\x01 \x03(\v2\x10.ecommerce.OrderR\x06orders\"\x99\x01\n" +
+	"\x11ListOrdersRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\x04R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x04R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0epayment_status\x18\x05 \x01(\tR\rpaymentStatus\"\xa9\x01\n" +
+	"\x19ListMerchantOrdersRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\x04R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x04R\x05limit\x12\x1f\n" +
+	"\vmerchant_id\x18\x03 \x01(\x04R\n" +
+	"merchantId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0epayment_status\x18\x05 \x01(\tR\rpaymentStatus\"l\n" +
+	"\x12ListOrdersResponse\x12(\n" +
+	"\x06orders\x18\x01 \x03(\v2\x10.ecommerce.OrderR\x06orders\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x04R\x06cursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x04R\x05total\"f\n" +
+	"\n" +
+	"RefundItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\"\xd2\x02\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x04R\aorderId\x12(\n" +
+	"\x10stripe_refund_id\x18\x03 \x01(\tR\x0estripeRefundId\x12\x1a\n" +
+	"\x06amount\x18\x04 \x01(\x02B\x02\x18\x01R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1c\n" +
+	"\trestocked\x18\a \x01(\bR\trestocked\x12+\n" +
+	"\x05items\x18\b \x03(\v2\x15.ecommerce.RefundItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12!\n" +
+	"\famount_minor\x18\n" +
+	" \x01(\x03R\vamountMinor\x12\x1a\n" +
+	"\bcurrency\x18\v \x01(\tR\bcurrency\"\x8e\x01\n" +
+	"\x12RefundOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12+\n" +
+	"\x05items\x18\x02 \x03(\v2\x15.ecommerce.RefundItemR\x05items\x12\x18\n" +
+	"\arestock\x18\x03 \x01(\bR\arestock\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"h\n" +
+	"\x13RefundOrderResponse\x12&\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.ecommerce.OrderR\x05order\x12)\n" +
+	"\x06refund\x18\x02 \x01(\v2\x11.ecommerce.RefundR\x06refund\"m\n" +
+	"\bMerchant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12*\n" +
+	"\x11stripe_account_id\x18\x02 \x01(\tR\x0fstripeAccountId\x12%\n" +
+	"\x0ecommission_bps\x18\x03 \x01(\rR\rcommissionBps\"O\n" +
+	"\x16OnboardMerchantRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\x04R\n" +
+	"merchantId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"q\n" +
+	"\x17OnboardMerchantResponse\x12/\n" +
+	"\bmerchant\x18\x01 \x01(\v2\x13.ecommerce.MerchantR\bmerchant\x12%\n" +
+	"\x0eonboarding_url\x18\x02 \x01(\tR\ronboardingUrl\"f\n" +
+	"\x1cSetMerchantCommissionRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\x04R\n" +
+	"merchantId\x12%\n" +
+	"\x0ecommission_bps\x18\x02 \x01(\rR\rcommissionBps\"\x8c\x02\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x04R\aorderId\x12 \n" +
+	"\fsub_order_id\x18\x03 \x01(\x04R\n" +
+	"subOrderId\x12\x1f\n" +
+	"\vmerchant_id\x18\x04 \x01(\x04R\n" +
+	"merchantId\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12(\n" +
+	"\x10stripe_object_id\x18\b \x01(\tR\x0estripeObjectId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"2\n" +
+	"\x15GetOrderLedgerRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\"J\n" +
+	"\x16GetOrderLedgerResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.ecommerce.LedgerEntryR\aentries\"\xfb\x01\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x04R\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05delta\x18\x05 \x01(\x03R\x05delta\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x04R\bquantity\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12!\n" +
+	"\freference_id\x18\b \x01(\x04R\vreferenceId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\xb9\x01\n" +
+	"\x16GetStockHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\x04R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x04R\x05limit\"i\n" +
+	"\x17GetStockHistoryResponse\x126\n" +
+	"\tmovements\x18\x01 \x03(\v2\x18.ecommerce.StockMovementR\tmovements\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x04R\x06cursor\"h\n" +
+	"\fShipmentItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\"\xc1\x02\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x04R\aorderId\x12\x1f\n" +
+	"\vmerchant_id\x18\x03 \x01(\x04R\n" +
+	"merchantId\x12\x18\n" +
+	"\acarrier\x18\x04 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x05 \x01(\tR\x0etrackingNumber\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12-\n" +
+	"\x05items\x18\a \x03(\v2\x17.ecommerce.ShipmentItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"shipped_at\x18\b \x01(\tR\tshippedAt\x12!\n" +
+	"\fdelivered_at\x18\t \x01(\tR\vdeliveredAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\"\xc5\x01\n" +
+	"\x15CreateShipmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x04 \x01(\tR\x0etrackingNumber\x12-\n" +
+	"\x05items\x18\x05 \x03(\v2\x17.ecommerce.ShipmentItemR\x05items\"\xa3\x01\n" +
+	"\x15UpdateShipmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x04 \x01(\tR\x0etrackingNumber\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"f\n" +
+	"\n" +
+	"ReturnItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x04R\tvariantId\"z\n" +
+	"\x12ReturnStatusChange\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\x04R\aactorId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\x9e\x03\n" +
+	"\x06Return\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x04R\aorderId\x12 \n" +
+	"\fsub_order_id\x18\x03 \x01(\x04R\n" +
+	"subOrderId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x1f\n" +
+	"\vmerchant_id\x18\x05 \x01(\x04R\n" +
+	"merchantId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12+\n" +
+	"\x05items\x18\b \x03(\v2\x15.ecommerce.ReturnItemR\x05items\x12\x1c\n" +
+	"\trestocked\x18\t \x01(\bR\trestocked\x12\x1b\n" +
+	"\trefund_id\x18\n" +
+	" \x01(\x04R\brefundId\x127\n" +
+	"\ahistory\x18\v \x03(\v2\x1d.ecommerce.ReturnStatusChangeR\ahistory\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\tR\tupdatedAt\"\x8f\x01\n" +
+	"\x14RequestReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12+\n" +
+	"\x05items\x18\x03 \x03(\v2\x15.ecommerce.ReturnItemR\x05items\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x8d\x01\n" +
+	"\x14ApproveReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x18\n" +
+	"\arestock\x18\x03 \x01(\bR\arestock\x12\x16\n" +
+	"\x06refund\x18\x04 \x01(\bR\x06refund\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"Z\n" +
+	"\x13RejectReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\x04R\n" +
+	"merchantId\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"\"\n" +
+	"\x10GetReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"4\n" +
+	"\x17ListOrderReturnsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\"G\n" +
+	"\x18ListOrderReturnsResponse\x12+\n" +
+	"\areturns\x18\x01 \x03(\v2\x11.ecommerce.ReturnR\areturns\"B\n" +
 	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"$\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"=\n" +
 	"\x12CancelOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\"L\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\"L\n" +
 	"\x1aUpdatePaymentStatusRequest\x12\x14\n" +
 	"\x05event\x18\x01 \x01(\tR\x05event\x12\x18\n" +
 	"\aorderId\x18\x02 \x01(\x04R\aorderId2\xce\x02\n" +
@@ -1940,18 +6614,48 @@ const file_ecommerce_proto_rawDesc = "" +
 	"\tEmptyCart\x12\x1b.ecommerce.EmptyCartRequest\x1a\x10.ecommerce.Empty\"\x00\x12>\n" +
 	"\n" +
 	"RemoveItem\x12\x1c.ecommerce.RemoveItemRequest\x1a\x10.ecommerce.Empty\"\x00\x12N\n" +
-	"\x12UpdateItemQuantity\x12$.ecommerce.UpdateItemQuantityRequest\x1a\x10.ecommerce.Empty\"\x002\xa9\x05\n" +
+	"\x12UpdateItemQuantity\x12$.ecommerce.UpdateItemQuantityRequest\x1a\x10.ecommerce.Empty\"\x002\xc6\x17\n" +
 	"\x0eProductService\x12Q\n" +
-	"\fListProducts\x12\x1e.ecommerce.ListProductsRequest\x1a\x1f.ecommerce.ListProductsResponse\"\x00\x12@\n" +
+	"\fListProducts\x12\x1e.ecommerce.ListProductsRequest\x1a\x1f.ecommerce.ListProductsResponse\"\x00\x12U\n" +
+	"\x0eSearchProducts\x12 .ecommerce.SearchProductsRequest\x1a\x1f.ecommerce.ListProductsResponse\"\x00\x12a\n" +
+	"\x14ListMerchantProducts\x12&.ecommerce.ListMerchantProductsRequest\x1a\x1f.ecommerce.ListProductsResponse\"\x00\x12@\n" +
 	"\n" +
 	"GetProduct\x12\x1c.ecommerce.GetProductRequest\x1a\x12.ecommerce.Product\"\x00\x12F\n" +
 	"\rCreateProduct\x12\x1f.ecommerce.CreateProductRequest\x1a\x12.ecommerce.Product\"\x00\x12D\n" +
-	"\rDeleteProduct\x12\x1f.ecommerce.DeleteProductRequest\x1a\x10.ecommerce.Empty\"\x00\x12F\n" +
+	"\rDeleteProduct\x12\x1f.ecommerce.DeleteProductRequest\x1a\x10.ecommerce.Empty\"\x00\x12H\n" +
+	"\x0eRestoreProduct\x12 .ecommerce.RestoreProductRequest\x1a\x12.ecommerce.Product\"\x00\x12F\n" +
 	"\rUpdateProduct\x12\x1f.ecommerce.UpdateProductRequest\x1a\x12.ecommerce.Product\"\x00\x12h\n" +
 	"\x13UpdateProductImages\x12%.ecommerce.UpdateProductImagesRequest\x1a&.ecommerce.UpdateProductImagesResponse\"\x00(\x01\x12u\n" +
-	"\x18ValidateProductInventory\x12*.ecommerce.ValidateProductInventoryRequest\x1a+.ecommerce.ValidateProductInventoryResponse\"\x00\x12K\n" +
+	"\x18ValidateProductInventory\x12*.ecommerce.ValidateProductInventoryRequest\x1a+.ecommerce.ValidateProductInventoryResponse\"\x00\x12Z\n" +
+	"\x0fGetStockHistory\x12!.ecommerce.GetStockHistoryRequest\x1a\".ecommerce.GetStockHistoryResponse\"\x00\x12N\n" +
+	"\x11SetProductOptions\x12#.ecommerce.SetProductOptionsRequest\x1a\x12.ecommerce.Product\"\x00\x12[\n" +
+	"\x14CreateProductVariant\x12&.ecommerce.CreateProductVariantRequest\x1a\x19.ecommerce.ProductVariant\"\x00\x12[\n" +
+	"\x14UpdateProductVariant\x12&.ecommerce.UpdateProductVariantRequest\x1a\x19.ecommerce.ProductVariant\"\x00\x12R\n" +
+	"\x14DeleteProductVariant\x12&.ecommerce.DeleteProductVariantRequest\x1a\x10.ecommerce.Empty\"\x00\x12I\n" +
+	"\x0eCreateCategory\x12 .ecommerce.CreateCategoryRequest\x1a\x13.ecommerce.Category\"\x00\x12I\n" +
+	"\x0eUpdateCategory\x12 .ecommerce.UpdateCategoryRequest\x1a\x13.ecommerce.Category\"\x00\x12F\n" +
+	"\x0eDeleteCategory\x12 .ecommerce.DeleteCategoryRequest\x1a\x10.ecommerce.Empty\"\x00\x12C\n" +
+	"\vGetCategory\x12\x1d.ecommerce.GetCategoryRequest\x1a\x13.ecommerce.Category\"\x00\x12W\n" +
+	"\x0eListCategories\x12 .ecommerce.ListCategoriesRequest\x1a!.ecommerce.ListCategoriesResponse\"\x00\x12T\n" +
+	"\x14SetProductCategories\x12&.ecommerce.SetProductCategoriesRequest\x1a\x12.ecommerce.Product\"\x00\x12K\n" +
+	"\n" +
+	"PlaceOrder\x12\x1c.ecommerce.PlaceOrderRequest\x1a\x1d.ecommerce.PlaceOrderResponse\"\x00\x12:\n" +
+	"\bGetOrder\x12\x1a.ecommerce.GetOrderRequest\x1a\x10.ecommerce.Order\"\x00\x12K\n" +
 	"\n" +
-	"PlaceOrder\x12\x1c.ecommerce.PlaceOrderRequest\x1a\x1d.ecommerce.PlaceOrderResponse\"\x002\xe0\x03\n" +
+	"ListOrders\x12\x1c.ecommerce.ListOrdersRequest\x1a\x1d.ecommerce.ListOrdersResponse\"\x00\x12[\n" +
+	"\x12ListMerchantOrders\x12$.ecommerce.ListMerchantOrdersRequest\x1a\x1d.ecommerce.ListOrdersResponse\"\x00\x12@\n" +
+	"\vCancelOrder\x12\x1d.ecommerce.CancelOrderRequest\x1a\x10.ecommerce.Order\"\x00\x12N\n" +
+	"\vRefundOrder\x12\x1d.ecommerce.RefundOrderRequest\x1a\x1e.ecommerce.RefundOrderResponse\"\x00\x12Z\n" +
+	"\x0fOnboardMerchant\x12!.ecommerce.OnboardMerchantRequest\x1a\".ecommerce.OnboardMerchantResponse\"\x00\x12W\n" +
+	"\x15SetMerchantCommission\x12'.ecommerce.SetMerchantCommissionRequest\x1a\x13.ecommerce.Merchant\"\x00\x12W\n" +
+	"\x0eGetOrderLedger\x12 .ecommerce.GetOrderLedgerRequest\x1a!.ecommerce.GetOrderLedgerResponse\"\x00\x12I\n" +
+	"\x0eCreateShipment\x12 .ecommerce.CreateShipmentRequest\x1a\x13.ecommerce.Shipment\"\x00\x12I\n" +
+	"\x0eUpdateShipment\x12 .ecommerce.UpdateShipmentRequest\x1a\x13.ecommerce.Shipment\"\x00\x12E\n" +
+	"\rRequestReturn\x12\x1f.ecommerce.RequestReturnRequest\x1a\x11.ecommerce.Return\"\x00\x12E\n" +
+	"\rApproveReturn\x12\x1f.ecommerce.ApproveReturnRequest\x1a\x11.ecommerce.Return\"\x00\x12C\n" +
+	"\fRejectReturn\x12\x1e.ecommerce.RejectReturnRequest\x1a\x11.ecommerce.Return\"\x00\x12=\n" +
+	"\tGetReturn\x12\x1b.ecommerce.GetReturnRequest\x1a\x11.ecommerce.Return\"\x00\x12]\n" +
+	"\x10ListOrderReturns\x12\".ecommerce.ListOrderReturnsRequest\x1a#.ecommerce.ListOrderReturnsResponse\"\x002\xe0\x03\n" +
 	"\fOrderService\x12:\n" +
 	"\bGetOrder\x12\x1a.ecommerce.GetOrderRequest\x1a\x10.ecommerce.Order\"\x00\x12T\n" +
 	"\x0fGetOrdersByUser\x12!.ecommerce.GetOrdersByUserRequest\x1a\x1c.ecommerce.GetOrdersResponse\"\x00\x12\\\n" +
@@ -1972,7 +6676,7 @@ func file_ecommerce_proto_rawDescGZIP() []byte {
 	return file_ecommerce_proto_rawDescData
 }
 
-var file_ecommerce_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_ecommerce_proto_msgTypes = make([]protoimpl.MessageInfo, 83)
 var file_ecommerce_proto_goTypes = []any{
 	(*CartItem)(nil),                         // 0: ecommerce.CartItem
 	(*AddItemRequest)(nil),                   // 1: ecommerce.AddItemRequest
@@ -1983,77 +6687,219 @@ var file_ecommerce_proto_goTypes = []any{
 	(*UpdateItemQuantityRequest)(nil),        // 6: ecommerce.UpdateItemQuantityRequest
 	(*Empty)(nil),                            // 7: ecommerce.Empty
 	(*Product)(nil),                          // 8: ecommerce.Product
-	(*UpdateProductImagesRequest)(nil),       // 9: ecommerce.UpdateProductImagesRequest
-	(*UpdateProductImagesResponse)(nil),      // 10: ecommerce.UpdateProductImagesResponse
-	(*CreateProductRequest)(nil),             // 11: ecommerce.CreateProductRequest
-	(*UpdateProductRequest)(nil),             // 12: ecommerce.UpdateProductRequest
-	(*DeleteProductRequest)(nil),             // 13: ecommerce.DeleteProductRequest
-	(*ListProductsResponse)(nil),             // 14: ecommerce.ListProductsResponse
-	(*ListProductsRequest)(nil),              // 15: ecommerce.ListProductsRequest
-	(*GetProductRequest)(nil),                // 16: ecommerce.GetProductRequest
-	(*ValidateProductInventoryRequest)(nil),  // 17: ecommerce.ValidateProductInventoryRequest
-	(*ValidateProductInventoryResponse)(nil), // 18: ecommerce.ValidateProductInventoryResponse
-	(*PlaceOrderRequest)(nil),                // 19: ecommerce.PlaceOrderRequest
-	(*PlaceOrderResponse)(nil),               // 20: ecommerce.PlaceOrderResponse
-	(*OrderItem)(nil),                        // 21: ecommerce.OrderItem
-	(*Order)(nil),                            // 22: ecommerce.Order
-	(*GetOrderRequest)(nil),                  // 23: ecommerce.GetOrderRequest
-	(*GetOrdersByUserRequest)(nil),           // 24: ecommerce.GetOrdersByUserRequest
-	(*GetOrdersByMerchantRequest)(nil),       // 25: ecommerce.GetOrdersByMerchantRequest
-	(*GetOrdersResponse)(nil),                // 26: ecommerce.GetOrdersResponse
-	(*UpdateOrderStatusRequest)(nil),         // 27: ecommerce.UpdateOrderStatusRequest
-	(*CancelOrderRequest)(nil),               // 28: ecommerce.CancelOrderRequest
-	(*UpdatePaymentStatusRequest)(nil),       // 29: ecommerce.UpdatePaymentStatusRequest
+	(*ProductSpecifications)(nil),            // 9: ecommerce.ProductSpecifications
+	(*ProductAttribute)(nil),                 // 10: ecommerce.ProductAttribute
+	(*AttributeFilter)(nil),                  // 11: ecommerce.AttributeFilter
+	(*ProductPrice)(nil),                     // 12: ecommerce.ProductPrice
+	(*ProductOption)(nil),                    // 13: ecommerce.ProductOption
+	(*ProductVariant)(nil),                   // 14: ecommerce.ProductVariant
+	(*Category)(nil),                         // 15: ecommerce.Category
+	(*CreateCategoryRequest)(nil),            // 16: ecommerce.CreateCategoryRequest
+	(*UpdateCategoryRequest)(nil),            // 17: ecommerce.UpdateCategoryRequest
+	(*DeleteCategoryRequest)(nil),            // 18: ecommerce.DeleteCategoryRequest
+	(*GetCategoryRequest)(nil),               // 19: ecommerce.GetCategoryRequest
+	(*ListCategoriesRequest)(nil),            // 20: ecommerce.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),           // 21: ecommerce.ListCategoriesResponse
+	(*SetProductCategoriesRequest)(nil),      // 22: ecommerce.SetProductCategoriesRequest
+	(*SetProductOptionsRequest)(nil),         // 23: ecommerce.SetProductOptionsRequest
+	(*CreateProductVariantRequest)(nil),      // 24: ecommerce.CreateProductVariantRequest
+	(*UpdateProductVariantRequest)(nil),      // 25: ecommerce.UpdateProductVariantRequest
+	(*DeleteProductVariantRequest)(nil),      // 26: ecommerce.DeleteProductVariantRequest
+	(*UpdateProductImagesRequest)(nil),       // 27: ecommerce.UpdateProductImagesRequest
+	(*UpdateProductImagesResponse)(nil),      // 28: ecommerce.UpdateProductImagesResponse
+	(*CreateProductRequest)(nil),             // 29: ecommerce.CreateProductRequest
+	(*UpdateProductRequest)(nil),             // 30: ecommerce.UpdateProductRequest
+	(*DeleteProductRequest)(nil),             // 31: ecommerce.DeleteProductRequest
+	(*ListProductsResponse)(nil),             // 32: ecommerce.ListProductsResponse
+	(*Facet)(nil),                            // 33: ecommerce.Facet
+	(*FacetValue)(nil),                       // 34: ecommerce.FacetValue
+	(*ListProductsRequest)(nil),              // 35: ecommerce.ListProductsRequest
+	(*SearchProductsRequest)(nil),            // 36: ecommerce.SearchProductsRequest
+	(*ListMerchantProductsRequest)(nil),      // 37: ecommerce.ListMerchantProductsRequest
+	(*RestoreProductRequest)(nil),            // 38: ecommerce.RestoreProductRequest
+	(*GetProductRequest)(nil),                // 39: ecommerce.GetProductRequest
+	(*ValidateProductInventoryRequest)(nil),  // 40: ecommerce.ValidateProductInventoryRequest
+	(*ValidateProductInventoryResponse)(nil), // 41: ecommerce.ValidateProductInventoryResponse
+	(*PlaceOrderRequest)(nil),                // 42: ecommerce.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),               // 43: ecommerce.PlaceOrderResponse
+	(*OrderItem)(nil),                        // 44: ecommerce.OrderItem
+	(*Order)(nil),                            // 45: ecommerce.Order
+	(*GetOrderRequest)(nil),                  // 46: ecommerce.GetOrderRequest
+	(*GetOrdersByUserRequest)(nil),           // 47: ecommerce.GetOrdersByUserRequest
+	(*GetOrdersByMerchantRequest)(nil),       // 48: ecommerce.GetOrdersByMerchantRequest
+	(*GetOrdersResponse)(nil),                // 49: ecommerce.GetOrdersResponse
+	(*ListOrdersRequest)(nil),                // 50: ecommerce.ListOrdersRequest
+	(*ListMerchantOrdersRequest)(nil),        // 51: ecommerce.ListMerchantOrdersRequest
+	(*ListOrdersResponse)(nil),               // 52: ecommerce.ListOrdersResponse
+	(*RefundItem)(nil),                       // 53: ecommerce.RefundItem
+	(*Refund)(nil),                           // 54: ecommerce.Refund
+	(*RefundOrderRequest)(nil),               // 55: ecommerce.RefundOrderRequest
+	(*RefundOrderResponse)(nil),              // 56: ecommerce.RefundOrderResponse
+	(*Merchant)(nil),                         // 57: ecommerce.Merchant
+	(*OnboardMerchantRequest)(nil),           // 58: ecommerce.OnboardMerchantRequest
+	(*OnboardMerchantResponse)(nil),          // 59: ecommerce.OnboardMerchantResponse
+	(*SetMerchantCommissionRequest)(nil),     // 60: ecommerce.SetMerchantCommissionRequest
+	(*LedgerEntry)(nil),                      // 61: ecommerce.LedgerEntry
+	(*GetOrderLedgerRequest)(nil),            // 62: ecommerce.GetOrderLedgerRequest
+	(*GetOrderLedgerResponse)(nil),           // 63: ecommerce.GetOrderLedgerResponse
+	(*StockMovement)(nil),                    // 64: ecommerce.StockMovement
+	(*GetStockHistoryRequest)(nil),           // 65: ecommerce.GetStockHistoryRequest
+	(*GetStockHistoryResponse)(nil),          // 66: ecommerce.GetStockHistoryResponse
+	(*ShipmentItem)(nil),                     // 67: ecommerce.ShipmentItem
+	(*Shipment)(nil),                         // 68: ecommerce.Shipment
+	(*CreateShipmentRequest)(nil),            // 69: ecommerce.CreateShipmentRequest
+	(*UpdateShipmentRequest)(nil),            // 70: ecommerce.UpdateShipmentRequest
+	(*ReturnItem)(nil),                       // 71: ecommerce.ReturnItem
+	(*ReturnStatusChange)(nil),               // 72: ecommerce.ReturnStatusChange
+	(*Return)(nil),                           // 73: ecommerce.Return
+	(*RequestReturnRequest)(nil),             // 74: ecommerce.RequestReturnRequest
+	(*ApproveReturnRequest)(nil),             // 75: ecommerce.ApproveReturnRequest
+	(*RejectReturnRequest)(nil),              // 76: ecommerce.RejectReturnRequest
+	(*GetReturnRequest)(nil),                 // 77: ecommerce.GetReturnRequest
+	(*ListOrderReturnsRequest)(nil),          // 78: ecommerce.ListOrderReturnsRequest
+	(*ListOrderReturnsResponse)(nil),         // 79: ecommerce.ListOrderReturnsResponse
+	(*UpdateOrderStatusRequest)(nil),         // 80: ecommerce.UpdateOrderStatusRequest
+	(*CancelOrderRequest)(nil),               // 81: ecommerce.CancelOrderRequest
+	(*UpdatePaymentStatusRequest)(nil),       // 82: ecommerce.UpdatePaymentStatusRequest
 }
 var file_ecommerce_proto_depIdxs = []int32{
 	0,  // 0: ecommerce.AddItemRequest.item:type_name -> ecommerce.CartItem
 	0,  // 1: ecommerce.Cart.items:type_name -> ecommerce.CartItem
-	8,  // 2: ecommerce.ListProductsResponse.products:type_name -> ecommerce.Product
-	21, // 3: ecommerce.Order.order_items:type_name -> ecommerce.OrderItem
-	22, // 4: ecommerce.GetOrdersResponse.orders:type_name -> ecommerce.Order
-	1,  // 5: ecommerce.CartService.AddItem:input_type -> ecommerce.AddItemRequest
-	3,  // 6: ecommerce.CartService.GetCart:input_type -> ecommerce.GetCartRequest
-	2,  // 7: ecommerce.CartService.EmptyCart:input_type -> ecommerce.EmptyCartRequest
-	5,  // 8: ecommerce.CartService.RemoveItem:input_type -> ecommerce.RemoveItemRequest
-	6,  // 9: ecommerce.CartService.UpdateItemQuantity:input_type -> ecommerce.UpdateItemQuantityRequest
-	15, // 10: ecommerce.ProductService.ListProducts:input_type -> ecommerce.ListProductsRequest
-	16, // 11: ecommerce.ProductService.GetProduct:input_type -> ecommerce.GetProductRequest
-	11, // 12: ecommerce.ProductService.CreateProduct:input_type -> ecommerce.CreateProductRequest
-	13, // 13: ecommerce.ProductService.DeleteProduct:input_type -> ecommerce.DeleteProductRequest
-	12, // 14: ecommerce.ProductService.UpdateProduct:input_type -> ecommerce.UpdateProductRequest
-	9,  // 15: ecommerce.ProductService.UpdateProductImages:input_type -> ecommerce.UpdateProductImagesRequest
-	17, // 16: ecommerce.ProductService.ValidateProductInventory:input_type -> ecommerce.ValidateProductInventoryRequest
-	19, // 17: ecommerce.ProductService.PlaceOrder:input_type -> ecommerce.PlaceOrderRequest
-	23, // 18: ecommerce.OrderService.GetOrder:input_type -> ecommerce.GetOrderRequest
-	24, // 19: ecommerce.OrderService.GetOrdersByUser:input_type -> ecommerce.GetOrdersByUserRequest
-	25, // 20: ecommerce.OrderService.GetOrdersByMerchant:input_type -> ecommerce.GetOrdersByMerchantRequest
-	27, // 21: ecommerce.OrderService.UpdateOrderStatus:input_type -> ecommerce.UpdateOrderStatusRequest
-	28, // 22: ecommerce.OrderService.CancelOrder:input_type -> ecommerce.CancelOrderRequest
-	29, // 23: ecommerce.OrderService.UpdatePaymentStatus:input_type -> ecommerce.UpdatePaymentStatusRequest
-	7,  // 24: ecommerce.CartService.AddItem:output_type -> ecommerce.Empty
-	4,  // 25: ecommerce.CartService.GetCart:output_type -> ecommerce.Cart
-	7,  // 26: ecommerce.CartService.EmptyCart:output_type -> ecommerce.Empty
-	7,  // 27: ecommerce.CartService.RemoveItem:output_type -> ecommerce.Empty
-	7,  // 28: ecommerce.CartService.UpdateItemQuantity:output_type -> ecommerce.Empty
-	14, // 29: ecommerce.ProductService.ListProducts:output_type -> ecommerce.ListProductsResponse
-	8,  // 30: ecommerce.ProductService.GetProduct:output_type -> ecommerce.Product
-	8,  // 31: ecommerce.ProductService.CreateProduct:output_type -> ecommerce.Product
-	7,  // 32: ecommerce.ProductService.DeleteProduct:output_type -> ecommerce.Empty
-	8,  // 33: ecommerce.ProductService.UpdateProduct:output_type -> ecommerce.Product
-	10, // 34: ecommerce.ProductService.UpdateProductImages:output_type -> ecommerce.UpdateProductImagesResponse
-	18, // 35: ecommerce.ProductService.ValidateProductInventory:output_type -> ecommerce.ValidateProductInventoryResponse
-	20, // 36: ecommerce.ProductService.PlaceOrder:output_type -> ecommerce.PlaceOrderResponse
-	22, // 37: ecommerce.OrderService.GetOrder:output_type -> ecommerce.Order
-	26, // 38: ecommerce.OrderService.GetOrdersByUser:output_type -> ecommerce.GetOrdersResponse
-	26, // 39: ecommerce.OrderService.GetOrdersByMerchant:output_type -> ecommerce.GetOrdersResponse
-	22, // 40: ecommerce.OrderService.UpdateOrderStatus:output_type -> ecommerce.Order
-	22, // 41: ecommerce.OrderService.CancelOrder:output_type -> ecommerce.Order
-	7,  // 42: ecommerce.OrderService.UpdatePaymentStatus:output_type -> ecommerce.Empty
-	24, // [24:43] is the sub-list for method output_type
-	5,  // [5:24] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	12, // 2: ecommerce.Product.prices:type_name -> ecommerce.ProductPrice
+	13, // 3: ecommerce.Product.options:type_name -> ecommerce.ProductOption
+	14, // 4: ecommerce.Product.variants:type_name -> ecommerce.ProductVariant
+	15, // 5: ecommerce.Product.categories:type_name -> ecommerce.Category
+	9,  // 6: ecommerce.Product.specifications:type_name -> ecommerce.ProductSpecifications
+	10, // 7: ecommerce.ProductSpecifications.attributes:type_name -> ecommerce.ProductAttribute
+	15, // 8: ecommerce.ListCategoriesResponse.categories:type_name -> ecommerce.Category
+	13, // 9: ecommerce.SetProductOptionsRequest.options:type_name -> ecommerce.ProductOption
+	12, // 10: ecommerce.CreateProductRequest.prices:type_name -> ecommerce.ProductPrice
+	9,  // 11: ecommerce.CreateProductRequest.specifications:type_name -> ecommerce.ProductSpecifications
+	12, // 12: ecommerce.UpdateProductRequest.prices:type_name -> ecommerce.ProductPrice
+	9,  // 13: ecommerce.UpdateProductRequest.specifications:type_name -> ecommerce.ProductSpecifications
+	8,  // 14: ecommerce.ListProductsResponse.products:type_name -> ecommerce.Product
+	33, // 15: ecommerce.ListProductsResponse.facets:type_name -> ecommerce.Facet
+	34, // 16: ecommerce.Facet.values:type_name -> ecommerce.FacetValue
+	11, // 17: ecommerce.ListProductsRequest.attributes:type_name -> ecommerce.AttributeFilter
+	44, // 18: ecommerce.Order.order_items:type_name -> ecommerce.OrderItem
+	45, // 19: ecommerce.Order.sub_orders:type_name -> ecommerce.Order
+	68, // 20: ecommerce.Order.shipments:type_name -> ecommerce.Shipment
+	45, // 21: ecommerce.GetOrdersResponse.orders:type_name -> ecommerce.Order
+	45, // 22: ecommerce.ListOrdersResponse.orders:type_name -> ecommerce.Order
+	53, // 23: ecommerce.Refund.items:type_name -> ecommerce.RefundItem
+	53, // 24: ecommerce.RefundOrderRequest.items:type_name -> ecommerce.RefundItem
+	45, // 25: ecommerce.RefundOrderResponse.order:type_name -> ecommerce.Order
+	54, // 26: ecommerce.RefundOrderResponse.refund:type_name -> ecommerce.Refund
+	57, // 27: ecommerce.OnboardMerchantResponse.merchant:type_name -> ecommerce.Merchant
+	61, // 28: ecommerce.GetOrderLedgerResponse.entries:type_name -> ecommerce.LedgerEntry
+	64, // 29: ecommerce.GetStockHistoryResponse.movements:type_name -> ecommerce.StockMovement
+	67, // 30: ecommerce.Shipment.items:type_name -> ecommerce.ShipmentItem
+	67, // 31: ecommerce.CreateShipmentRequest.items:type_name -> ecommerce.ShipmentItem
+	71, // 32: ecommerce.Return.items:type_name -> ecommerce.ReturnItem
+	72, // 33: ecommerce.Return.history:type_name -> ecommerce.ReturnStatusChange
+	71, // 34: ecommerce.RequestReturnRequest.items:type_name -> ecommerce.ReturnItem
+	73, // 35: ecommerce.ListOrderReturnsResponse.returns:type_name -> ecommerce.Return
+	1,  // 36: ecommerce.CartService.AddItem:input_type -> ecommerce.AddItemRequest
+	3,  // 37: ecommerce.CartService.GetCart:input_type -> ecommerce.GetCartRequest
+	2,  // 38: ecommerce.CartService.EmptyCart:input_type -> ecommerce.EmptyCartRequest
+	5,  // 39: ecommerce.CartService.RemoveItem:input_type -> ecommerce.RemoveItemRequest
+	6,  // 40: ecommerce.CartService.UpdateItemQuantity:input_type -> ecommerce.UpdateItemQuantityRequest
+	35, // 41: ecommerce.ProductService.ListProducts:input_type -> ecommerce.ListProductsRequest
+	36, // 42: ecommerce.ProductService.SearchProducts:input_type -> ecommerce.SearchProductsRequest
+	37, // 43: ecommerce.ProductService.ListMerchantProducts:input_type -> ecommerce.ListMerchantProductsRequest
+	39, // 44: ecommerce.ProductService.GetProduct:input_type -> ecommerce.GetProductRequest
+	29, // 45: ecommerce.ProductService.CreateProduct:input_type -> ecommerce.CreateProductRequest
+	31, // 46: ecommerce.ProductService.DeleteProduct:input_type -> ecommerce.DeleteProductRequest
+	38, // 47: ecommerce.ProductService.RestoreProduct:input_type -> ecommerce.RestoreProductRequest
+	30, // 48: ecommerce.ProductService.UpdateProduct:input_type -> ecommerce.UpdateProductRequest
+	27, // 49: ecommerce.ProductService.UpdateProductImages:input_type -> ecommerce.UpdateProductImagesRequest
+	40, // 50: ecommerce.ProductService.ValidateProductInventory:input_type -> ecommerce.ValidateProductInventoryRequest
+	65, // 51: ecommerce.ProductService.GetStockHistory:input_type -> ecommerce.GetStockHistoryRequest
+	23, // 52: ecommerce.ProductService.SetProductOptions:input_type -> ecommerce.SetProductOptionsRequest
+	24, // 53: ecommerce.ProductService.CreateProductVariant:input_type -> ecommerce.CreateProductVariantRequest
+	25, // 54: ecommerce.ProductService.UpdateProductVariant:input_type -> ecommerce.UpdateProductVariantRequest
+	26, // 55: ecommerce.ProductService.DeleteProductVariant:input_type -> ecommerce.DeleteProductVariantRequest
+	16, // 56: ecommerce.ProductService.CreateCategory:input_type -> ecommerce.CreateCategoryRequest
+	17, // 57: ecommerce.ProductService.UpdateCategory:input_type -> ecommerce.UpdateCategoryRequest
+	18, // 58: ecommerce.ProductService.DeleteCategory:input_type -> ecommerce.DeleteCategoryRequest
+	19, // 59: ecommerce.ProductService.GetCategory:input_type -> ecommerce.GetCategoryRequest
+	20, // 60: ecommerce.ProductService.ListCategories:input_type -> ecommerce.ListCategoriesRequest
+	22, // 61: ecommerce.ProductService.SetProductCategories:input_type -> ecommerce.SetProductCategoriesRequest
+	42, // 62: ecommerce.ProductService.PlaceOrder:input_type -> ecommerce.PlaceOrderRequest
+	46, // 63: ecommerce.ProductService.GetOrder:input_type -> ecommerce.GetOrderRequest
+	50, // 64: ecommerce.ProductService.ListOrders:input_type -> ecommerce.ListOrdersRequest
+	51, // 65: ecommerce.ProductService.ListMerchantOrders:input_type -> ecommerce.ListMerchantOrdersRequest
+	81, // 66: ecommerce.ProductService.CancelOrder:input_type -> ecommerce.CancelOrderRequest
+	55, // 67: ecommerce.ProductService.RefundOrder:input_type -> ecommerce.RefundOrderRequest
+	58, // 68: ecommerce.ProductService.OnboardMerchant:input_type -> ecommerce.OnboardMerchantRequest
+	60, // 69: ecommerce.ProductService.SetMerchantCommission:input_type -> ecommerce.SetMerchantCommissionRequest
+	62, // 70: ecommerce.ProductService.GetOrderLedger:input_type -> ecommerce.GetOrderLedgerRequest
+	69, // 71: ecommerce.ProductService.CreateShipment:input_type -> ecommerce.CreateShipmentRequest
+	70, // 72: ecommerce.ProductService.UpdateShipment:input_type -> ecommerce.UpdateShipmentRequest
+	74, // 73: ecommerce.ProductService.RequestReturn:input_type -> ecommerce.RequestReturnRequest
+	75, // 74: ecommerce.ProductService.ApproveReturn:input_type -> ecommerce.ApproveReturnRequest
+	76, // 75: ecommerce.ProductService.RejectReturn:input_type -> ecommerce.RejectReturnRequest
+	77, // 76: ecommerce.ProductService.GetReturn:input_type -> ecommerce.GetReturnRequest
+	78, // 77: ecommerce.ProductService.ListOrderReturns:input_type -> ecommerce.ListOrderReturnsRequest
+	46, // 78: ecommerce.OrderService.GetOrder:input_type -> ecommerce.GetOrderRequest
+	47, // 79: ecommerce.OrderService.GetOrdersByUser:input_type -> ecommerce.GetOrdersByUserRequest
+	48, // 80: ecommerce.OrderService.GetOrdersByMerchant:input_type -> ecommerce.GetOrdersByMerchantRequest
+	80, // 81: ecommerce.OrderService.UpdateOrderStatus:input_type -> ecommerce.UpdateOrderStatusRequest
+	81, // 82: ecommerce.OrderService.CancelOrder:input_type -> ecommerce.CancelOrderRequest
+	82, // 83: ecommerce.OrderService.UpdatePaymentStatus:input_type -> ecommerce.UpdatePaymentStatusRequest
+	7,  // 84: ecommerce.CartService.AddItem:output_type -> ecommerce.Empty
+	4,  // 85: ecommerce.CartService.GetCart:output_type -> ecommerce.Cart
+	7,  // 86: ecommerce.CartService.EmptyCart:output_type -> ecommerce.Empty
+	7,  // 87: ecommerce.CartService.RemoveItem:output_type -> ecommerce.Empty
+	7,  // 88: ecommerce.CartService.UpdateItemQuantity:output_type -> ecommerce.Empty
+	32, // 89: ecommerce.ProductService.ListProducts:output_type -> ecommerce.ListProductsResponse
+	32, // 90: ecommerce.ProductService.SearchProducts:output_type -> ecommerce.ListProductsResponse
+	32, // 91: ecommerce.ProductService.ListMerchantProducts:output_type -> ecommerce.ListProductsResponse
+	8,  // 92: ecommerce.ProductService.GetProduct:output_type -> ecommerce.Product
+	8,  // 93: ecommerce.ProductService.CreateProduct:output_type -> ecommerce.Product
+	7,  // 94: ecommerce.ProductService.DeleteProduct:output_type -> ecommerce.Empty
+	8,  // 95: ecommerce.ProductService.RestoreProduct:output_type -> ecommerce.Product
+	8,  // 96: ecommerce.ProductService.UpdateProduct:output_type -> ecommerce.Product
+	28, // 97: ecommerce.ProductService.UpdateProductImages:output_type -> ecommerce.UpdateProductImagesResponse
+	41, // 98: ecommerce.ProductService.ValidateProductInventory:output_type -> ecommerce.ValidateProductInventoryResponse
+	66, // 99: ecommerce.ProductService.GetStockHistory:output_type -> ecommerce.GetStockHistoryResponse
+	8,  // 100: ecommerce.ProductService.SetProductOptions:output_type -> ecommerce.Product
+	14, // 101: ecommerce.ProductService.CreateProductVariant:output_type -> ecommerce.ProductVariant
+	14, // 102: ecommerce.ProductService.UpdateProductVariant:output_type -> ecommerce.ProductVariant
+	7,  // 103: ecommerce.ProductService.DeleteProductVariant:output_type -> ecommerce.Empty
+	15, // 104: ecommerce.ProductService.CreateCategory:output_type -> ecommerce.Category
+	15, // 105: ecommerce.ProductService.UpdateCategory:output_type -> ecommerce.Category
+	7,  // 106: ecommerce.ProductService.DeleteCategory:output_type -> ecommerce.Empty
+	15, // 107: ecommerce.ProductService.GetCategory:output_type -> ecommerce.Category
+	21, // 108: ecommerce.ProductService.ListCategories:output_type -> ecommerce.ListCategoriesResponse
+	8,  // 109: ecommerce.ProductService.SetProductCategories:output_type -> ecommerce.Product
+	43, // 110: ecommerce.ProductService.PlaceOrder:output_type -> ecommerce.PlaceOrderResponse
+	45, // 111: ecommerce.ProductService.GetOrder:output_type -> ecommerce.Order
+	52, // 112: ecommerce.ProductService.ListOrders:output_type -> ecommerce.ListOrdersResponse
+	52, // 113: ecommerce.ProductService.ListMerchantOrders:output_type -> ecommerce.ListOrdersResponse
+	45, // 114: ecommerce.ProductService.CancelOrder:output_type -> ecommerce.Order
+	56, // 115: ecommerce.ProductService.RefundOrder:output_type -> ecommerce.RefundOrderResponse
+	59, // 116: ecommerce.ProductService.OnboardMerchant:output_type -> ecommerce.OnboardMerchantResponse
+	57, // 117: ecommerce.ProductService.SetMerchantCommission:output_type -> ecommerce.Merchant
+	63, // 118: ecommerce.ProductService.GetOrderLedger:output_type -> ecommerce.GetOrderLedgerResponse
+	68, // 119: ecommerce.ProductService.CreateShipment:output_type -> ecommerce.Shipment
+	68, // 120: ecommerce.ProductService.UpdateShipment:output_type -> ecommerce.Shipment
+	73, // 121: ecommerce.ProductService.RequestReturn:output_type -> ecommerce.Return
+	73, // 122: ecommerce.ProductService.ApproveReturn:output_type -> ecommerce.Return
+	73, // 123: ecommerce.ProductService.RejectReturn:output_type -> ecommerce.Return
+	73, // 124: ecommerce.ProductService.GetReturn:output_type -> ecommerce.Return
+	79, // 125: ecommerce.ProductService.ListOrderReturns:output_type -> ecommerce.ListOrderReturnsResponse
+	45, // 126: ecommerce.OrderService.GetOrder:output_type -> ecommerce.Order
+	49, // 127: ecommerce.OrderService.GetOrdersByUser:output_type -> ecommerce.GetOrdersResponse
+	49, // 128: ecommerce.OrderService.GetOrdersByMerchant:output_type -> ecommerce.GetOrdersResponse
+	45, // 129: ecommerce.OrderService.UpdateOrderStatus:output_type -> ecommerce.Order
+	45, // 130: ecommerce.OrderService.CancelOrder:output_type -> ecommerce.Order
+	7,  // 131: ecommerce.OrderService.UpdatePaymentStatus:output_type -> ecommerce.Empty
+	84, // [84:132] is the sub-list for method output_type
+	36, // [36:84] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_ecommerce_proto_init() }
@@ -2067,7 +6913,7 @@ func file_ecommerce_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ecommerce_proto_rawDesc), len(file_ecommerce_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   30,
+			NumMessages:   83,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
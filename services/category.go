@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var (
+	slugPattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type CategoryService struct{}
+
+func NewCategoryService() *CategoryService {
+	return &CategoryService{}
+}
+
+func (c *CategoryService) CreateCategory(req *pb.CreateCategoryRequest) (*pb.Category, error) {
+	category := &models.Category{
+		Name:        strings.TrimSpace(req.GetName()),
+		Slug:        req.GetSlug(),
+		Description: req.GetDescription(),
+	}
+	if req.GetCommissionBps() != 0 {
+		commissionBps := req.GetCommissionBps()
+		category.CommissionBps = &commissionBps
+	}
+	if req.GetParentId() != 0 {
+		parentId := req.GetParentId()
+		category.ParentId = &parentId
+	}
+	if err := c.validateCategory(category); err != nil {
+		return nil, err
+	}
+
+	category, err := storage.StorageInstance.Category.CreateCategory(category, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save category: %w", err)
+	}
+	return storage.CategoryDBToGrpc(category), nil
+}
+
+// UpdateCategory replaces the fields of the category, moving it and everything below it when the parent changes
+func (c *CategoryService) UpdateCategory(req *pb.UpdateCategoryRequest) (*pb.Category, error) {
+	existing, err := c.getCategory(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	category := &models.Category{
+		Id:          existing.Id,
+		Name:        strings.TrimSpace(req.GetName()),
+		Slug:        req.GetSlug(),
+		Description: req.GetDescription(),
+		CreatedAt:   existing.CreatedAt,
+	}
+	if req.GetCommissionBps() != 0 {
+		commissionBps := req.GetCommissionBps()
+		category.CommissionBps = &commissionBps
+	}
+	if req.GetParentId() != 0 {
+		parentId := req.GetParentId()
+		category.ParentId = &parentId
+
+		// A category can not be moved below itself
+		descendants, err := storage.StorageInstance.Category.ListDescendantIds(category.Id, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list descendants: %w", err)
+		}
+		if slices.Contains(descendants, parentId) {
+			return nil, status.Error(codes.InvalidArgument, "a category can not be moved below itself")
+		}
+	}
+	if err := c.validateCategory(category); err != nil {
+		return nil, err
+	}
+
+	if err := storage.StorageInstance.Category.UpdateCategory(category, nil); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+	category, err = storage.StorageInstance.Category.GetCategory(category.Id, storage.StorageInstance.GetWriteDB())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return storage.CategoryDBToGrpc(category), nil
+}
+
+// DeleteCategory removes a category without children, its products stay in their other categories
+func (c *CategoryService) DeleteCategory(id uint64) error {
+	if _, err := c.getCategory(id); err != nil {
+		return err
+	}
+
+	children := id
+	categories, err := storage.StorageInstance.Category.ListCategories(&children)
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+	if len(categories) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "category %d still has %d subcategories", id, len(categories))
+	}
+
+	if err := storage.StorageInstance.Category.DeleteCategory(id, nil); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return nil
+}
+
+func (c *CategoryService) GetCategory(req *pb.GetCategoryRequest) (*pb.Category, error) {
+	if req.GetId() == 0 {
+		category, err := storage.StorageInstance.Category.GetCategoryBySlug(req.GetSlug(), nil)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "category %s not found", req.GetSlug())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		return storage.CategoryDBToGrpc(category), nil
+	}
+
+	category, err := c.getCategory(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return storage.CategoryDBToGrpc(category), nil
+}
+
+func (c *CategoryService) ListCategories(req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	var parentId *uint64
+	if !req.GetAll() {
+		id := req.GetParentId()
+		parentId = &id
+	}
+
+	categories, err := storage.StorageInstance.Category.ListCategories(parentId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	return &pb.ListCategoriesResponse{
+		Categories: storage.CategoryDBsToGrpcs(categories),
+	}, nil
+}
+
+// SetProductCategories replaces the categories a merchant's product is listed in
+func (c *CategoryService) SetProductCategories(req *pb.SetProductCategoriesRequest) (*pb.Product, error) {
+	product, err := getMerchantProduct(req.GetProductId(), req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := c.setProductCategories(product.Id, req.GetCategoryIds())
+	if err != nil {
+		return nil, err
+	}
+	product.Categories = categories
+	return storage.DBToGrpc(product), nil
+}
+
+func (c *CategoryService) setProductCategories(productId uint64, categoryIds []uint64) ([]models.Category, error) {
+	categoryIds = slices.Compact(slices.Sorted(slices.Values(categoryIds)))
+	categories, err := storage.StorageInstance.Category.SetProductCategories(productId, categoryIds, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.InvalidArgument, "product can only be added to existing categories")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set product categories: %w", err)
+	}
+	return categories, nil
+}
+
+func (c *CategoryService) getCategory(id uint64) (*models.Category, error) {
+	category, err := storage.StorageInstance.Category.GetCategory(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "category %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// validateCategory checks the name, that the parent exists and fills in or checks the slug
+func (c *CategoryService) validateCategory(category *models.Category) error {
+	if category.Name == "" {
+		return status.Error(codes.InvalidArgument, "category name is required")
+	}
+	if category.CommissionBps != nil && *category.CommissionBps > 10000 {
+		return status.Error(codes.InvalidArgument, "commission can not exceed 10000 basis points")
+	}
+
+	if category.Slug == "" {
+		category.Slug = slugify(category.Name)
+	}
+	category.Slug = strings.ToLower(category.Slug)
+	if !slugPattern.MatchString(category.Slug) {
+		return status.Errorf(codes.InvalidArgument, "invalid slug %q, use lowercase letters, digits and dashes", category.Slug)
+	}
+	existing, err := storage.StorageInstance.Category.GetCategoryBySlug(category.Slug, nil)
+	if err == nil && existing.Id != category.Id {
+		return status.Errorf(codes.AlreadyExists, "slug %s is already used by category %d", category.Slug, existing.Id)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+
+	if category.ParentId != nil {
+		if _, err := storage.StorageInstance.Category.GetCategory(*category.ParentId, nil); errors.Is(err, gorm.ErrRecordNotFound) {
+			return status.Errorf(codes.InvalidArgument, "parent category %d not found", *category.ParentId)
+		} else if err != nil {
+			return fmt.Errorf("failed to get parent category: %w", err)
+		}
+	}
+	return nil
+}
+
+// slugify turns a name like "Men's Shoes" into "men-s-shoes"
+func slugify(name string) string {
+	return strings.Trim(slugSeparator.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
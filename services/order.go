@@ -1,19 +1,39 @@
 package services
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"product/auth"
 	"product/configs"
 	"product/models"
 	pb "product/proto"
 	"product/storage"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/checkout/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 )
 
+// checkoutSessionTTL is how long a buyer has to complete the Stripe checkout
+const checkoutSessionTTL = 30 * time.Minute
+
+// reservationGrace keeps stock reserved a little longer than the checkout session lasts,
+// so a payment made at the last moment still finds its stock when the webhook arrives.
+const reservationGrace = 5 * time.Minute
+
+// delayedPaymentTTL keeps stock reserved for a buyer who paid with a delayed payment method,
+// such as a bank debit, while the money is on its way.
+const delayedPaymentTTL = 7 * 24 * time.Hour
+
 // PaymentItem represents an item with corresponding quantity in a payment
 type PaymentItem struct {
 	StripePriceId string `json:"stripe_price_id"`
@@ -33,8 +53,17 @@ func NewOrderService(cartClient *CartService) *OrderService {
 func (o *OrderService) PlaceOrder(req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
 	resp := &pb.PlaceOrderResponse{}
 
+	// Return the original checkout if this is a retry
+	requestHash, err := o.hashPlaceOrderRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := o.findIdempotentOrder(req, requestHash, nil); existing != nil || err != nil {
+		return existing, err
+	}
+
 	// Validate address
-	err := NewGoogleMapsService().ValidateAddress(req.Address, req.Country)
+	err = NewGoogleMapsService().ValidateAddress(req.Address, req.Country)
 	if err != nil {
 		return nil, fmt.Errorf("invalid address: %w", err)
 	}
@@ -45,9 +74,9 @@ func (o *OrderService) PlaceOrder(req *pb.PlaceOrderRequest) (*pb.PlaceOrderResp
 		return resp, fmt.Errorf("failed to get cart: %w", err)
 	}
 
-	// Sort cart by Product ID to prevent deadlocks
+	// Sort cart by Product and Variant ID to prevent deadlocks
 	// Incrementally lock product rows
-	o.sortCartByProductId(cart.Items)
+	o.sortCart(cart.Items)
 
 	// Validate cart is not empty
 	if len(cart.Items) == 0 {
@@ -56,16 +85,30 @@ func (o *OrderService) PlaceOrder(req *pb.PlaceOrderRequest) (*pb.PlaceOrderResp
 
 	// Create the order
 	order := &models.Order{
-		UserId:  req.UserId,
-		Status:  models.OrderStatusProcessing,
-		Address: req.Address,
+		UserId:          req.UserId,
+		Status:          models.OrderStatusProcessing,
+		Address:         req.Address,
+		IdempotencyKey:  req.IdempotencyKey,
+		IdempotencyHash: requestHash,
 	}
 
 	// Start transaction
 	tx := storage.StorageInstance.BeginTransaction()
 
+	// A concurrent request with the same key may have placed the order while we were validating
+	if req.IdempotencyKey != "" {
+		if err := storage.StorageInstance.Order.LockIdempotencyKey(req.UserId, req.IdempotencyKey, tx); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to lock idempotency key: %w", err)
+		}
+		if existing, err := o.findIdempotentOrder(req, requestHash, tx); existing != nil || err != nil {
+			tx.Rollback()
+			return existing, err
+		}
+	}
+
 	// Validate cart and get payment items
-	paymentItem, err := o.validateCartAndGetPaymentItems(req.UserId, order, cart.Items, tx)
+	paymentItem, err := o.validateCartAndGetPaymentItems(req.UserId, buyerCurrency(req.Country), order, cart.Items, tx)
 	if err != nil {
 		tx.Rollback()
 		return resp, fmt.Errorf("failed to validate cart: %w", err)
@@ -78,6 +121,14 @@ func (o *OrderService) PlaceOrder(req *pb.PlaceOrderRequest) (*pb.PlaceOrderResp
 		return nil, fmt.Errorf("failed to save order: %w", err)
 	}
 
+	// Hand each merchant their own lines
+	for _, subOrder := range order.SubOrders {
+		if err := storage.StorageInstance.Order.AssignSubOrderItems(order.Id, subOrder.Id, subOrder.MerchantId, tx); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to assign items to sub-order: %w", err)
+		}
+	}
+
 	// Create payment session
 	sess, err := o.createNewPayment(order.Id, req.UserEmail, paymentItem)
 	if err != nil {
@@ -87,6 +138,7 @@ func (o *OrderService) PlaceOrder(req *pb.PlaceOrderRequest) (*pb.PlaceOrderResp
 
 	// attach checkout session ID
 	order.CheckoutSessionId = sess.ID
+	order.CheckoutUrl = sess.URL
 	if err := storage.StorageInstance.Order.UpdateOrder(order, tx); err != nil {
 		tx.Rollback()
 		return resp, fmt.Errorf("unable to update order with checkout session id: %w", err)
@@ -107,56 +159,557 @@ func (o *OrderService) PlaceOrder(req *pb.PlaceOrderRequest) (*pb.PlaceOrderResp
 	return resp, nil
 }
 
-func (o *OrderService) validateCartAndGetPaymentItems(buyerId uint64, order *models.Order, cartItems []*pb.CartItem, tx *gorm.DB) ([]*PaymentItem, error) {
+// findIdempotentOrder returns the response of the order previously placed with the request's idempotency key.
+// Returns nil if the key has not been used yet.
+func (o *OrderService) findIdempotentOrder(req *pb.PlaceOrderRequest, requestHash string, tx *gorm.DB) (*pb.PlaceOrderResponse, error) {
+	if req.IdempotencyKey == "" {
+		return nil, nil
+	}
+
+	order, err := storage.StorageInstance.Order.GetOrderByIdempotencyKey(req.UserId, req.IdempotencyKey, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order by idempotency key: %w", err)
+	}
+
+	if order.IdempotencyHash != requestHash {
+		return nil, status.Error(codes.AlreadyExists, "idempotency key was already used for a different order")
+	}
+	return &pb.PlaceOrderResponse{CheckoutUrl: order.CheckoutUrl}, nil
+}
+
+// hashPlaceOrderRequest fingerprints the request so a reused key with a different payload is detected
+func (o *OrderService) hashPlaceOrderRequest(req *pb.PlaceOrderRequest) (string, error) {
+	fingerprint := proto.Clone(req).(*pb.PlaceOrderRequest)
+	fingerprint.IdempotencyKey = ""
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(fingerprint)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (o *OrderService) GetOrder(id uint64) (*pb.Order, error) {
+	order, err := storage.StorageInstance.Order.GetOrder(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "order %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return storage.OrderDBToGrpc(order), nil
+}
+
+// GetOrderFor gets an order the caller is part of, as its buyer or the merchant of a sub-order, admins get any order.
+// Orders of others are reported as not found so their IDs can not be probed.
+func (o *OrderService) GetOrderFor(id uint64, identity *auth.Identity) (*pb.Order, error) {
+	order, err := storage.StorageInstance.Order.GetOrder(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "order %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var merchantId uint64
+	if order.IsSubOrder() {
+		merchantId = order.MerchantId
+	}
+	if !canSee(identity, order.UserId, merchantId) {
+		return nil, status.Errorf(codes.NotFound, "order %d not found", id)
+	}
+	return storage.OrderDBToGrpc(order), nil
+}
+
+// canSee tells if the caller is the buyer or the merchant of something, admins see everything.
+// merchantId is 0 when there is no single merchant, like for the buyer's whole order.
+func canSee(identity *auth.Identity, userId, merchantId uint64) bool {
+	switch {
+	case identity.HasRole(auth.RoleAdmin):
+		return true
+	case identity.UserId == userId:
+		return true
+	case merchantId != 0 && identity.UserId == merchantId && identity.HasRole(auth.RoleMerchant):
+		return true
+	}
+	return false
+}
+
+func (o *OrderService) ListOrders(req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	filter := storage.OrderFilter{
+		Status:        models.OrderStatus(req.GetStatus()),
+		PaymentStatus: models.PaymentStatus(req.GetPaymentStatus()),
+	}
+	orders, cursor, total, err := storage.StorageInstance.Order.ListByUserId(req.GetUserId(), filter, pageLimit(req.GetLimit()), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListOrdersResponse{
+		Orders: storage.OrderDBsToGrpcs(orders),
+		Cursor: cursor,
+		Total:  total,
+	}, nil
+}
+
+func (o *OrderService) ListMerchantOrders(req *pb.ListMerchantOrdersRequest) (*pb.ListOrdersResponse, error) {
+	filter := storage.OrderFilter{
+		Status:        models.OrderStatus(req.GetStatus()),
+		PaymentStatus: models.PaymentStatus(req.GetPaymentStatus()),
+	}
+	orders, cursor, total, err := storage.StorageInstance.Order.ListByMerchantId(req.GetMerchantId(), filter, pageLimit(req.GetLimit()), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListOrdersResponse{
+		Orders: storage.OrderDBsToGrpcs(orders),
+		Cursor: cursor,
+		Total:  total,
+	}, nil
+}
+
+// CancelOrder cancels an order that has not been paid yet on behalf of the buyer.
+// The checkout session is expired so it can no longer be paid, and the stock is released.
+func (o *OrderService) CancelOrder(req *pb.CancelOrderRequest) (*pb.Order, error) {
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(req.GetId(), tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, status.Errorf(codes.NotFound, "order %d not found", req.GetId())
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order.UserId != req.GetUserId() {
+		tx.Rollback()
+		return nil, status.Error(codes.PermissionDenied, "order does not belong to user")
+	}
+	if order.IsSubOrder() {
+		tx.Rollback()
+		return nil, status.Errorf(codes.InvalidArgument, "order %d is a merchant sub-order, cancel order %d instead", order.Id, *order.ParentId)
+	}
+
+	switch {
+	case order.Status == models.OrderStatusCancelled:
+		tx.Rollback()
+		return o.GetOrder(order.Id)
+	case order.PaymentStatus == models.PaymentStatusCompleted:
+		tx.Rollback()
+		return nil, status.Error(codes.FailedPrecondition, "order has already been paid and can no longer be cancelled")
+	case order.Status != models.OrderStatusProcessing:
+		tx.Rollback()
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s can not be cancelled", order.Status)
+	}
+
+	if order.CheckoutSessionId != "" {
+		if err := expireCheckoutSession(order.CheckoutSessionId); err != nil {
+			tx.Rollback()
+			if errors.Is(err, errCheckoutSessionCompleted) {
+				return nil, status.Error(codes.FailedPrecondition, "order has already been paid and can no longer be cancelled")
+			}
+			return nil, err
+		}
+	}
+
+	if err := cancelUnpaidOrder(order, models.UserStockActor(req.GetUserId()), tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return o.GetOrder(order.Id)
+}
+
+func (o *OrderService) validateCartAndGetPaymentItems(buyerId uint64, buyerCurrency string, order *models.Order, cartItems []*pb.CartItem, tx *gorm.DB) ([]*PaymentItem, error) {
 	paymentItems := make([]*PaymentItem, 0, len(cartItems))
 	orderItems := make([]models.OrderItem, 0, len(cartItems))
+	subOrders := make([]models.Order, 0)
+	subOrderIndex := make(map[uint64]int)  // merchant ID -> index in subOrders
+	commissions := make(map[uint64]uint32) // product ID -> commission in basis points
 
+	expiresAt := time.Now().Add(checkoutSessionTTL + reservationGrace)
+	lines := make([]cartLine, 0, len(cartItems))
 	for _, item := range cartItems {
-		product, err := storage.StorageInstance.Product.GetWithLock(item.Id, tx)
+		line, err := lockCartLine(item, tx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get product: %w", err)
+			return nil, err
 		}
+		lines = append(lines, line)
+	}
 
-		if product.MerchantId == buyerId {
-			return nil, fmt.Errorf("cannot buy your own product: %s", product.Name)
+	// A checkout session is charged in a single currency
+	currency, err := chooseCurrency(buyerCurrency, lines)
+	if err != nil {
+		return nil, err
+	}
+	order.Currency = currency
+
+	// Reserve the stock until the order is paid, products with variants are stocked per variant.
+	// A line can be in the cart more than once, its quantities are checked and reserved together.
+	quantities := make(map[models.LineKey]uint64, len(lines))
+	lockedLines := make(map[models.LineKey]cartLine, len(lines))
+	for i, item := range cartItems {
+		quantities[lines[i].key()] += item.Quantity
+		lockedLines[lines[i].key()] = lines[i]
+	}
+	for _, key := range sortedLines(quantities) {
+		product, variant := lockedLines[key].product, lockedLines[key].variant
+		inventory := product.Inventory
+		if variant != nil {
+			inventory = variant.Inventory
 		}
-
-		if product.Inventory < item.Quantity {
+		available, err := availableStock(key.ProductId, key.VariantId, inventory, tx)
+		if err != nil {
+			return nil, err
+		}
+		if available < quantities[key] {
+			if variant != nil {
+				return nil, fmt.Errorf("not enough stock for product: %s (%s)", product.Name, variant.Sku)
+			}
 			return nil, fmt.Errorf("not enough stock for product: %s", product.Name)
 		}
+		order.Reservations = append(order.Reservations, models.Reservation{
+			ProductId: key.ProductId,
+			VariantId: key.VariantId,
+			Quantity:  quantities[key],
+			ExpiresAt: expiresAt,
+		})
+	}
 
-		// Update inventory
-		updatedInventory := product.Inventory - item.Quantity
-		err = storage.StorageInstance.Product.UpdateInventory(product.Id, updatedInventory, tx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to update inventory: %w", err)
+	for i, item := range cartItems {
+		product, variant := lines[i].product, lines[i].variant
+		priceMinor, stripePriceId, _ := product.VariantPriceIn(variant, currency)
+
+		if product.MerchantId == buyerId {
+			return nil, fmt.Errorf("cannot buy your own product: %s", product.Name)
+		}
+
+		var variantId uint64
+		var sku string
+		if variant != nil {
+			variantId, sku = variant.Id, variant.Sku
 		}
 
 		paymentItems = append(paymentItems, &PaymentItem{
-			StripePriceId: product.StripePriceId,
+			StripePriceId: stripePriceId,
 			Quantity:      item.Quantity,
 		})
 
+		// Lock in the commission at the time of sale
+		commissionBps, ok := commissions[product.Id]
+		if !ok {
+			commissionBps, err = NewPayoutService().CommissionBps(product, tx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commission: %w", err)
+			}
+			commissions[product.Id] = commissionBps
+		}
+
 		// Add Order Items
 		orderItems = append(orderItems, models.OrderItem{
-			ProductId: product.Id,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
+			ProductId:     product.Id,
+			VariantId:     variantId,
+			Sku:           sku,
+			Quantity:      item.Quantity,
+			PriceMinor:    priceMinor,
+			CommissionBps: commissionBps,
 		})
 
 		// Update Total
-		order.Total += product.Price * float32(item.Quantity)
+		order.TotalMinor += priceMinor * int64(item.Quantity)
+
+		// Group the line into the merchant's sub-order
+		index, ok := subOrderIndex[product.MerchantId]
+		if !ok {
+			index = len(subOrders)
+			subOrderIndex[product.MerchantId] = index
+			subOrders = append(subOrders, models.Order{
+				MerchantId:    product.MerchantId,
+				UserId:        order.UserId,
+				Status:        order.Status,
+				PaymentStatus: models.PaymentStatusPending,
+				Address:       order.Address,
+				Currency:      currency,
+			})
+		}
+		subOrders[index].TotalMinor += priceMinor * int64(item.Quantity)
 	}
 
-	// Save order items to database
+	// Save order items and merchant sub-orders to database
 	order.OrderItems = orderItems
+	order.SubOrders = subOrders
 
 	return paymentItems, nil
 }
 
-func (o *OrderService) sortCartByProductId(cartItems []*pb.CartItem) {
+// buyerCurrency is the currency buyers from the country pay in
+func buyerCurrency(country string) string {
+	if currency, ok := configs.COUNTRY_CURRENCIES[strings.ToUpper(country)]; ok {
+		return currency
+	}
+	return configs.DEFAULT_CURRENCY
+}
+
+// cartLine is the locked product, and the chosen variant of it, a cart item is for
+type cartLine struct {
+	product *models.Product
+	variant *models.ProductVariant
+}
+
+// key identifies the line the cart item is for
+func (l cartLine) key() models.LineKey {
+	key := models.LineKey{ProductId: l.product.Id}
+	if l.variant != nil {
+		key.VariantId = l.variant.Id
+	}
+	return key
+}
+
+// lockCartLine locks the product of the cart item and then its variant.
+// Products that have variants can only be bought as one of them.
+func lockCartLine(item *pb.CartItem, tx *gorm.DB) (cartLine, error) {
+	product, err := storage.StorageInstance.Product.GetWithLock(item.Id, tx)
+	if err != nil {
+		return cartLine{}, fmt.Errorf("failed to get product: %w", err)
+	}
+	if !product.IsPublished(time.Now()) {
+		return cartLine{}, status.Errorf(codes.FailedPrecondition, "product %s is not available", product.Name)
+	}
+	if item.VariantId == 0 {
+		if len(product.Variants) > 0 {
+			return cartLine{}, status.Errorf(codes.InvalidArgument, "choose a variant of product %s", product.Name)
+		}
+		return cartLine{product: product}, nil
+	}
+
+	variant, err := storage.StorageInstance.Variant.GetVariantWithLock(item.VariantId, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && variant.ProductId != product.Id) {
+		return cartLine{}, status.Errorf(codes.InvalidArgument, "variant %d of product %s not found", item.VariantId, product.Name)
+	}
+	if err != nil {
+		return cartLine{}, fmt.Errorf("failed to get variant: %w", err)
+	}
+	return cartLine{product: product, variant: variant}, nil
+}
+
+// chooseCurrency charges the buyer in their own currency when every line is sold in it,
+// otherwise in the base currency the products share.
+func chooseCurrency(buyerCurrency string, lines []cartLine) (string, error) {
+	candidates := []string{buyerCurrency}
+	if len(lines) > 0 && lines[0].product.Currency != buyerCurrency {
+		candidates = append(candidates, lines[0].product.Currency)
+	}
+
+	for _, currency := range candidates {
+		available := true
+		for _, line := range lines {
+			if _, _, ok := line.product.VariantPriceIn(line.variant, currency); !ok {
+				available = false
+				break
+			}
+		}
+		if available {
+			return currency, nil
+		}
+	}
+	return "", status.Errorf(codes.FailedPrecondition, "the cart can not be paid in %s or a single other currency", buyerCurrency)
+}
+
+// errCheckoutSessionCompleted is returned when the buyer already paid through the session
+var errCheckoutSessionCompleted = errors.New("checkout session is already completed")
+
+// expireCheckoutSession makes sure the checkout session can no longer be paid.
+func expireCheckoutSession(sessionId string) error {
+	sess, err := session.Get(sessionId, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get checkout session: %w", err)
+	}
+
+	switch sess.Status {
+	case stripe.CheckoutSessionStatusComplete:
+		return errCheckoutSessionCompleted
+	case stripe.CheckoutSessionStatusOpen:
+		if _, err := session.Expire(sessionId, nil); err != nil {
+			return fmt.Errorf("failed to expire checkout session: %w", err)
+		}
+	}
+	return nil
+}
+
+// awaitDelayedPayment records that the buyer completed the checkout with a delayed payment method
+// and keeps their stock reserved until the payment succeeds or fails.
+// The order must have been loaded with GetOrderWithLock inside tx.
+func awaitDelayedPayment(order *models.Order, tx *gorm.DB) error {
+	order.PaymentStatus = models.PaymentStatusProcessing
+	if err := updateOrderStatus(order, tx); err != nil {
+		return err
+	}
+	if err := storage.StorageInstance.Reservation.Extend(order.Id, time.Now().Add(delayedPaymentTTL), tx); err != nil {
+		return fmt.Errorf("failed to extend reservations: %w", err)
+	}
+	return nil
+}
+
+// cancelUnpaidOrder marks an order that was never paid as cancelled and releases its stock.
+// The actor is recorded in the stock ledger when stock goes back to the inventory.
+// The order must have been loaded with GetOrderWithLock inside tx.
+func cancelUnpaidOrder(order *models.Order, actor string, tx *gorm.DB) error {
+	order.Status = models.OrderStatusCancelled
+	order.PaymentStatus = models.PaymentStatusCancelled
+	if err := updateOrderStatus(order, tx); err != nil {
+		return err
+	}
+
+	reservations, err := storage.StorageInstance.Reservation.Release(order.Id, tx)
+	if err != nil {
+		return fmt.Errorf("failed to release reservations: %w", err)
+	}
+	if len(reservations) > 0 {
+		return nil
+	}
+	// Orders placed before stock was reserved took it out of the inventory right away
+	return releaseOrderInventory(order, actor, tx)
+}
+
+// convertReservations takes the stock reserved for a paid order out of the inventory.
+// Orders placed before stock was reserved have no reservations, their stock was already taken.
+// The order must have been loaded with GetOrderWithLock inside tx.
+func convertReservations(order *models.Order, tx *gorm.DB) error {
+	reservations, err := storage.StorageInstance.Reservation.Release(order.Id, tx)
+	if err != nil {
+		return fmt.Errorf("failed to release reservations: %w", err)
+	}
+	quantities := make(map[models.LineKey]uint64, len(reservations))
+	for _, reservation := range reservations {
+		quantities[reservation.Key()] += reservation.Quantity
+	}
+
+	source := models.StockMovement{Type: models.StockMovementOrderPlaced, Actor: models.UserStockActor(order.UserId), ReferenceId: order.Id}
+	return takeLines(quantities, source, tx)
+}
+
+// takeLines takes quantities out of the inventory of products and variants, as far as it goes,
+// and records a movement for each line, copied from source, in the stock ledger.
+// Rows are locked in product and then variant ID order like restockLines.
+func takeLines(quantities map[models.LineKey]uint64, source models.StockMovement, tx *gorm.DB) error {
+	movements := make([]*models.StockMovement, 0, len(quantities))
+	for _, line := range sortedLines(quantities) {
+		var inventory, missing uint64
+		var err error
+		if line.VariantId != 0 {
+			inventory, missing, err = storage.StorageInstance.Variant.TakeVariantStockWithLock(line.VariantId, quantities[line], tx)
+		} else {
+			inventory, missing, err = storage.StorageInstance.Product.TakeStockWithLock(line.ProductId, quantities[line], tx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to take stock of product %d: %w", line.ProductId, err)
+		}
+		// Only possible when the stock was sold again in the meantime, e.g. after the reservation of a late payment expired
+		if missing > 0 {
+			log.Printf("%s %d: oversold %d of product %d variant %d", source.Type, source.ReferenceId, missing, line.ProductId, line.VariantId)
+		}
+		// The ledger holds what actually left the inventory, the oversold part never was in it
+		if taken := quantities[line] - missing; taken > 0 {
+			movements = append(movements, stockMovement(source, line, -int64(taken), inventory))
+		}
+	}
+	return recordStock(movements, tx)
+}
+
+// availableStock is the part of the inventory of a product or variant that is not reserved.
+// Lock the product or variant row inside tx first when stock is about to be reserved.
+func availableStock(productId, variantId, inventory uint64, tx *gorm.DB) (uint64, error) {
+	reserved, err := storage.StorageInstance.Reservation.Reserved(productId, variantId, time.Now(), tx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reserved stock: %w", err)
+	}
+	if reserved >= inventory {
+		return 0, nil
+	}
+	return inventory - reserved, nil
+}
+
+// updateOrderStatus saves the status of a buyer's order and cascades it to the merchant sub-orders.
+// Refunds set the status of each sub-order from its own lines with updateRefundStatus instead.
+// The order must have been loaded with GetOrderWithLock inside tx.
+func updateOrderStatus(order *models.Order, tx *gorm.DB) error {
+	if err := storage.StorageInstance.Order.UpdateOrder(order, tx); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	for i := range order.SubOrders {
+		subOrder := &order.SubOrders[i]
+		subOrder.Status = order.Status
+		subOrder.PaymentStatus = order.PaymentStatus
+		if err := storage.StorageInstance.Order.UpdateOrder(subOrder, tx); err != nil {
+			return fmt.Errorf("failed to update sub-order: %w", err)
+		}
+	}
+	return nil
+}
+
+// releaseOrderInventory adds the quantities of the order items back to their products.
+func releaseOrderInventory(order *models.Order, actor string, tx *gorm.DB) error {
+	quantities := make(map[models.LineKey]uint64, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		quantities[item.Key()] += item.Quantity
+	}
+	return restockLines(quantities, models.StockMovement{Type: models.StockMovementOrderCancelled, Actor: actor, ReferenceId: order.Id}, tx)
+}
+
+// restockLines adds quantities back to the inventory of products and variants
+// and records a movement for each line, copied from source, in the stock ledger.
+// Rows are locked in product and then variant ID order, the same order PlaceOrder locks them in, to prevent deadlocks.
+func restockLines(quantities map[models.LineKey]uint64, source models.StockMovement, tx *gorm.DB) error {
+	movements := make([]*models.StockMovement, 0, len(quantities))
+	for _, line := range sortedLines(quantities) {
+		if quantities[line] == 0 {
+			continue
+		}
+		var inventory uint64
+		var err error
+		if line.VariantId != 0 {
+			if inventory, err = storage.StorageInstance.Variant.RestockVariantWithLock(line.VariantId, quantities[line], tx); err != nil {
+				return fmt.Errorf("failed to restock variant %d: %w", line.VariantId, err)
+			}
+		} else if inventory, err = storage.StorageInstance.Product.RestockWithLock(line.ProductId, quantities[line], tx); err != nil {
+			return fmt.Errorf("failed to restock product %d: %w", line.ProductId, err)
+		}
+		movements = append(movements, stockMovement(source, line, int64(quantities[line]), inventory))
+	}
+	return recordStock(movements, tx)
+}
+
+// sortedLines lists the lines by product and then variant ID, the order their rows are locked in
+func sortedLines(quantities map[models.LineKey]uint64) []models.LineKey {
+	lines := make([]models.LineKey, 0, len(quantities))
+	for line := range quantities {
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].ProductId != lines[j].ProductId {
+			return lines[i].ProductId < lines[j].ProductId
+		}
+		return lines[i].VariantId < lines[j].VariantId
+	})
+	return lines
+}
+
+// sortCart orders the cart by product and then variant ID, the order rows are locked in
+func (o *OrderService) sortCart(cartItems []*pb.CartItem) {
 	sort.Slice(cartItems, func(i, j int) bool {
-		return cartItems[i].Id < cartItems[j].Id
+		if cartItems[i].Id != cartItems[j].Id {
+			return cartItems[i].Id < cartItems[j].Id
+		}
+		return cartItems[i].VariantId < cartItems[j].VariantId
 	})
 }
 
@@ -170,7 +723,7 @@ func (o *OrderService) createNewPayment(orderId uint64, email string, paymentIte
 		})
 	}
 
-	expiresAt := time.Now().Add(30 * time.Minute).Unix() // default 30 mins to checkout
+	expiresAt := time.Now().Add(checkoutSessionTTL).Unix()
 
 	// Custom metadata for Stripe Event
 	metadata := map[string]string{
@@ -190,6 +743,11 @@ func (o *OrderService) createNewPayment(orderId uint64, email string, paymentIte
 		ExpiresAt:     stripe.Int64(expiresAt),
 		CustomerEmail: stripe.String(email),
 		Metadata:      metadata,
+		// Copy metadata onto the PaymentIntent so payment_intent.* events can be matched to the order
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			Metadata:      metadata,
+			TransferGroup: stripe.String(transferGroup(orderId)),
+		},
 	}
 
 	sess, err := session.New(params)
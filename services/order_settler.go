@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"product/storage"
+	"time"
+)
+
+// settleBatchSize caps how many unsettled orders are handled per run
+const settleBatchSize = 100
+
+// OrderSettler periodically settles paid orders whose merchants were not paid,
+// because settling right after the payment webhook failed or never ran, or because
+// a merchant had no connected account yet.
+type OrderSettler struct {
+	interval time.Duration
+}
+
+func NewOrderSettler(interval time.Duration) *OrderSettler {
+	return &OrderSettler{
+		interval: interval,
+	}
+}
+
+// Start runs the settler forever, it is meant to be called in its own goroutine
+func (s *OrderSettler) Start() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.Settle(); err != nil {
+			log.Println("order settler: ", err)
+		}
+	}
+}
+
+// Settle settles the paid orders that are still unsettled one interval after they were last updated.
+// Every unsettled order is visited, orders waiting on a merchant to onboard can not hold up the others.
+func (s *OrderSettler) Settle() error {
+	// Leave the webhook that marked the order paid one interval to settle it itself
+	cutoff := time.Now().Add(-s.interval)
+	var afterId uint64
+	for {
+		orders, err := storage.StorageInstance.Order.ListUnsettledOrders(cutoff, afterId, settleBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list unsettled orders: %w", err)
+		}
+
+		for _, order := range orders {
+			if err := NewPayoutService().SettleOrder(order.Id); err != nil {
+				log.Printf("order settler: failed to settle order %d: %v", order.Id, err)
+			}
+		}
+		if len(orders) < settleBatchSize {
+			return nil
+		}
+		afterId = orders[len(orders)-1].Id
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"product/models"
+	"product/storage"
+	"slices"
+	"time"
+)
+
+// sweepBatchSize caps how many stale orders are handled per sweep
+const sweepBatchSize = 100
+
+// OrderSweeper periodically cancels orders whose stock reservation expired, or whose checkout session
+// expired without a webhook being delivered, releasing their stock for other buyers.
+type OrderSweeper struct {
+	interval time.Duration
+}
+
+func NewOrderSweeper(interval time.Duration) *OrderSweeper {
+	return &OrderSweeper{
+		interval: interval,
+	}
+}
+
+// Start runs the sweeper forever, it is meant to be called in its own goroutine
+func (s *OrderSweeper) Start() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.Sweep(); err != nil {
+			log.Println("order sweeper: ", err)
+		}
+	}
+}
+
+// Sweep releases the orders holding expired reservations and every processing order older than the checkout session lifetime
+func (s *OrderSweeper) Sweep() error {
+	orderIds, err := storage.StorageInstance.Reservation.ListExpiredOrderIds(time.Now(), sweepBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list expired reservations: %w", err)
+	}
+
+	// Give the expiry webhook one interval to arrive before stepping in
+	cutoff := time.Now().Add(-checkoutSessionTTL - reservationGrace - s.interval)
+	orders, err := storage.StorageInstance.Order.ListStaleOrders(models.OrderStatusProcessing, cutoff, sweepBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list stale orders: %w", err)
+	}
+	for _, order := range orders {
+		if !slices.Contains(orderIds, order.Id) {
+			orderIds = append(orderIds, order.Id)
+		}
+	}
+
+	for _, orderId := range orderIds {
+		if err := s.releaseOrder(orderId); err != nil {
+			log.Printf("order sweeper: failed to release order %d: %v", orderId, err)
+		}
+	}
+	return nil
+}
+
+func (s *OrderSweeper) releaseOrder(orderId uint64) error {
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(orderId, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	// Re-check under the lock, a webhook may have handled it in the meantime
+	if order.Status != models.OrderStatusProcessing || order.PaymentStatus == models.PaymentStatusCompleted {
+		tx.Rollback()
+		return nil
+	}
+
+	// Make sure the buyer can no longer pay for stock we are giving back
+	if order.CheckoutSessionId != "" {
+		err := expireCheckoutSession(order.CheckoutSessionId)
+		if errors.Is(err, errCheckoutSessionCompleted) {
+			// Paid, possibly with a delayed payment method, the payment webhooks are responsible for this order.
+			// Keep its stock until they arrive and stop sweeping it.
+			if err := awaitDelayedPayment(order, tx); err != nil {
+				tx.Rollback()
+				return err
+			}
+			if err := tx.Commit().Error; err != nil {
+				return fmt.Errorf("failed to commit transaction: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := cancelUnpaidOrder(order, models.StockActorSystem, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
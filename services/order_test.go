@@ -0,0 +1,319 @@
+package services
+
+import (
+	"product/auth"
+	"product/configs"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// stockedProducts serves products from memory
+type stockedProducts struct {
+	storage.ProductInterface
+	products map[uint64]*models.Product
+}
+
+func (s *stockedProducts) GetWithLock(id uint64, tx *gorm.DB) (*models.Product, error) {
+	product, ok := s.products[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *product
+	return &copied, nil
+}
+
+func (s *stockedProducts) Get(id uint64, tx *gorm.DB) (*models.Product, error) {
+	return s.GetWithLock(id, tx)
+}
+
+func (s *stockedProducts) RestockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, error) {
+	product := s.products[id]
+	product.Inventory += quantity
+	return product.Inventory, nil
+}
+
+func (s *stockedProducts) TakeStockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, uint64, error) {
+	product := s.products[id]
+	taken := min(quantity, product.Inventory)
+	product.Inventory -= taken
+	return product.Inventory, quantity - taken, nil
+}
+
+// heldReservations holds unexpired stock per line and the reservations of each order
+type heldReservations struct {
+	storage.ReservationInterface
+	held     map[models.LineKey]uint64
+	expired  []uint64
+	byOrder  map[uint64][]models.Reservation
+	released []uint64
+	extended map[uint64]time.Time
+}
+
+func (h *heldReservations) Reserved(productId, variantId uint64, now time.Time, tx *gorm.DB) (uint64, error) {
+	return h.held[models.LineKey{ProductId: productId, VariantId: variantId}], nil
+}
+
+func (h *heldReservations) ListExpiredOrderIds(now time.Time, limit int) ([]uint64, error) {
+	return h.expired, nil
+}
+
+func (h *heldReservations) Extend(orderId uint64, expiresAt time.Time, tx *gorm.DB) error {
+	if h.extended == nil {
+		h.extended = map[uint64]time.Time{}
+	}
+	h.extended[orderId] = expiresAt
+	return nil
+}
+
+func (h *heldReservations) Release(orderId uint64, tx *gorm.DB) ([]models.Reservation, error) {
+	h.released = append(h.released, orderId)
+	reservations := h.byOrder[orderId]
+	delete(h.byOrder, orderId)
+	return reservations, nil
+}
+
+// noMerchants has no merchant settings, every merchant pays the default commission
+type noMerchants struct {
+	storage.MerchantInterface
+}
+
+func (noMerchants) GetMerchant(id uint64, tx *gorm.DB) (*models.Merchant, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+// savedOrders keeps orders in memory
+type savedOrders struct {
+	storage.OrderInterface
+	orders map[uint64]*models.Order
+	stale  []*models.Order
+	// limits are the page sizes listings were asked for
+	limits []uint64
+}
+
+func (s *savedOrders) ListByUserId(userId uint64, filter storage.OrderFilter, limit uint64, cursorID uint64) ([]*models.Order, uint64, uint64, error) {
+	s.limits = append(s.limits, limit)
+	return nil, 0, 0, nil
+}
+
+func (s *savedOrders) ListByMerchantId(merchantId uint64, filter storage.OrderFilter, limit uint64, cursorID uint64) ([]*models.Order, uint64, uint64, error) {
+	s.limits = append(s.limits, limit)
+	return nil, 0, 0, nil
+}
+
+func (s *savedOrders) GetOrderWithLock(id uint64, tx *gorm.DB) (*models.Order, error) {
+	order, ok := s.orders[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return order, nil
+}
+
+func (s *savedOrders) GetOrder(id uint64, tx *gorm.DB) (*models.Order, error) {
+	return s.GetOrderWithLock(id, tx)
+}
+
+func (s *savedOrders) UpdateOrder(order *models.Order, tx *gorm.DB) error {
+	s.orders[order.Id] = order
+	return nil
+}
+
+func (s *savedOrders) GetOrderByCheckoutSessionId(sessionId string, tx *gorm.DB) (*models.Order, error) {
+	for _, order := range s.orders {
+		if order.CheckoutSessionId == sessionId {
+			return order, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *savedOrders) MarkSettled(id uint64, settledAt time.Time, tx *gorm.DB) error {
+	s.orders[id].SettledAt = &settledAt
+	return nil
+}
+
+func (s *savedOrders) ListUnsettledOrders(before time.Time, afterId uint64, limit int) ([]*models.Order, error) {
+	var orders []*models.Order
+	for _, order := range s.orders {
+		if order.SettledAt == nil && order.PaymentStatus == models.PaymentStatusCompleted && order.Id > afterId {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (s *savedOrders) UpdateOrderItem(item *models.OrderItem, tx *gorm.DB) error {
+	return nil
+}
+
+func (s *savedOrders) ListStaleOrders(status models.OrderStatus, before time.Time, limit int) ([]*models.Order, error) {
+	return s.stale, nil
+}
+
+func TestValidateCartReservesRepeatedLinesTogether(t *testing.T) {
+	configs.DEFAULT_CURRENCY = "SGD"
+	store, _ := useTestStorage(t)
+	store.Product = &stockedProducts{products: map[uint64]*models.Product{
+		1: {Id: 1, MerchantId: 9, Name: "Mug", Currency: "SGD", PriceMinor: 500, StripePriceId: "price_mug", Inventory: 3, Status: models.ProductStatusPublished},
+	}}
+	reservations := &heldReservations{held: map[models.LineKey]uint64{}}
+	store.Reservation = reservations
+	store.Merchant = noMerchants{}
+
+	// Each line alone fits in the inventory, together they do not
+	cart := []*pb.CartItem{{Id: 1, Quantity: 2}, {Id: 1, Quantity: 2}}
+	if _, err := NewOrderService(nil).validateCartAndGetPaymentItems(5, "SGD", &models.Order{}, cart, nil); err == nil || !strings.Contains(err.Error(), "not enough stock") {
+		t.Fatalf("reserving 4 of 3 in stock: got error %v, want not enough stock", err)
+	}
+
+	// Stock held by other orders is not available
+	reservations.held[models.LineKey{ProductId: 1}] = 1
+	cart = []*pb.CartItem{{Id: 1, Quantity: 2}, {Id: 1, Quantity: 1}}
+	if _, err := NewOrderService(nil).validateCartAndGetPaymentItems(5, "SGD", &models.Order{}, cart, nil); err == nil {
+		t.Fatal("reserving 3 of 3 in stock with 1 held: got no error")
+	}
+
+	reservations.held[models.LineKey{ProductId: 1}] = 0
+	order := &models.Order{}
+	before := time.Now()
+	if _, err := NewOrderService(nil).validateCartAndGetPaymentItems(5, "SGD", order, cart, nil); err != nil {
+		t.Fatalf("reserving 3 of 3 in stock: %v", err)
+	}
+	if len(order.Reservations) != 1 || order.Reservations[0].Quantity != 3 {
+		t.Fatalf("reservations %+v, want a single one of 3", order.Reservations)
+	}
+	// The reservation outlives the checkout session so a last-moment payment still finds its stock
+	expiresAt := order.Reservations[0].ExpiresAt
+	if earliest := before.Add(checkoutSessionTTL + reservationGrace); expiresAt.Before(earliest) {
+		t.Errorf("reservation expires at %v, want at least %v", expiresAt, earliest)
+	}
+	if len(order.OrderItems) != 2 {
+		t.Errorf("got %d order items, want one per cart line", len(order.OrderItems))
+	}
+}
+
+func TestSweepCancelsOrdersWithExpiredReservations(t *testing.T) {
+	store, pool := useTestStorage(t)
+	orders := &savedOrders{orders: map[uint64]*models.Order{
+		7: {Id: 7, Status: models.OrderStatusProcessing, PaymentStatus: models.PaymentStatusPending},
+	}}
+	store.Order = orders
+	reservations := &heldReservations{
+		expired: []uint64{7},
+		byOrder: map[uint64][]models.Reservation{7: {{OrderId: 7, ProductId: 1, Quantity: 2}}},
+	}
+	store.Reservation = reservations
+
+	if err := NewOrderSweeper(time.Minute).Sweep(); err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	order := orders.orders[7]
+	if order.Status != models.OrderStatusCancelled || order.PaymentStatus != models.PaymentStatusCancelled {
+		t.Errorf("order is %s/%s, want cancelled", order.Status, order.PaymentStatus)
+	}
+	if len(reservations.released) != 1 || reservations.released[0] != 7 {
+		t.Errorf("released the reservations of orders %v, want [7]", reservations.released)
+	}
+	// The inventory was never decremented, releasing the reservation is all it takes
+	if pool.committed != 1 {
+		t.Errorf("committed %d transactions, want 1", pool.committed)
+	}
+}
+
+func TestSweepKeepsStockOfDelayedPayments(t *testing.T) {
+	store, pool := useTestStorage(t)
+	orders := &savedOrders{orders: map[uint64]*models.Order{
+		7: {Id: 7, Status: models.OrderStatusProcessing, PaymentStatus: models.PaymentStatusPending, CheckoutSessionId: "cs_7"},
+	}}
+	store.Order = orders
+	reservations := &heldReservations{
+		expired: []uint64{7},
+		byOrder: map[uint64][]models.Reservation{7: {{OrderId: 7, ProductId: 1, Quantity: 2}}},
+	}
+	store.Reservation = reservations
+	fake := useTestStripe(t)
+	fake.sessionStatus = stripe.CheckoutSessionStatusComplete
+
+	if err := NewOrderSweeper(time.Minute).Sweep(); err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	order := orders.orders[7]
+	if order.Status != models.OrderStatusProcessing || order.PaymentStatus != models.PaymentStatusProcessing {
+		t.Errorf("order is %s/%s, want processing/processing", order.Status, order.PaymentStatus)
+	}
+	if len(reservations.released) != 0 {
+		t.Errorf("released the reservations of orders %v, want none", reservations.released)
+	}
+	if expiresAt, ok := reservations.extended[7]; !ok || !expiresAt.After(time.Now().Add(delayedPaymentTTL-time.Minute)) {
+		t.Errorf("reservations of order 7 expire at %v, want them held for the delayed payment", expiresAt)
+	}
+	if pool.committed != 1 {
+		t.Errorf("committed %d transactions, want 1", pool.committed)
+	}
+}
+
+func TestListOrdersLimitsPageSize(t *testing.T) {
+	store, _ := useTestStorage(t)
+	orders := &savedOrders{}
+	store.Order = orders
+
+	service := NewOrderService(nil)
+	if _, err := service.ListOrders(&pb.ListOrdersRequest{UserId: 5}); err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if _, err := service.ListMerchantOrders(&pb.ListMerchantOrdersRequest{MerchantId: 9, Limit: 1000}); err != nil {
+		t.Fatalf("ListMerchantOrders: %v", err)
+	}
+	if want := []uint64{defaultPageLimit, maxPageLimit}; !slices.Equal(orders.limits, want) {
+		t.Errorf("listed pages of %v orders, want %v", orders.limits, want)
+	}
+}
+
+func TestGetOrderForOnlyShowsOrdersOfTheCaller(t *testing.T) {
+	store, _ := useTestStorage(t)
+	parentId := uint64(1)
+	store.Order = &savedOrders{orders: map[uint64]*models.Order{
+		1: {Id: 1, UserId: 5},
+		2: {Id: 2, UserId: 5, ParentId: &parentId, MerchantId: 9},
+	}}
+
+	buyer := &auth.Identity{UserId: 5, Roles: []auth.Role{auth.RoleBuyer}}
+	otherBuyer := &auth.Identity{UserId: 6, Roles: []auth.Role{auth.RoleBuyer}}
+	merchant := &auth.Identity{UserId: 9, Roles: []auth.Role{auth.RoleMerchant}}
+	otherMerchant := &auth.Identity{UserId: 8, Roles: []auth.Role{auth.RoleMerchant}}
+	admin := &auth.Identity{UserId: 1, Roles: []auth.Role{auth.RoleAdmin}}
+
+	for _, tc := range []struct {
+		name     string
+		identity *auth.Identity
+		orderId  uint64
+		visible  bool
+	}{
+		{"buyer order", buyer, 1, true},
+		{"buyer sub-order", buyer, 2, true},
+		{"other buyer", otherBuyer, 1, false},
+		{"merchant sub-order", merchant, 2, true},
+		{"merchant whole order", merchant, 1, false},
+		{"other merchant", otherMerchant, 2, false},
+		{"admin", admin, 1, true},
+	} {
+		_, err := NewOrderService(nil).GetOrderFor(tc.orderId, tc.identity)
+		if tc.visible && err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		if !tc.visible && status.Code(err) != codes.NotFound {
+			t.Errorf("%s: got error %v, want NotFound", tc.name, err)
+		}
+	}
+}
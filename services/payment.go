@@ -0,0 +1,187 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"product/models"
+	"product/storage"
+	"strconv"
+
+	"github.com/stripe/stripe-go/v81"
+	"gorm.io/gorm"
+)
+
+// errUnknownOrder is returned for events that do not reference one of our orders
+var errUnknownOrder = errors.New("event does not reference an order")
+
+type PaymentService struct{}
+
+func NewPaymentService() *PaymentService {
+	return &PaymentService{}
+}
+
+// HandleStripeEvent applies a verified Stripe webhook event to the matching order.
+// Events that were already processed are ignored.
+func (p *PaymentService) HandleStripeEvent(event *stripe.Event) error {
+	switch event.Type {
+	case stripe.EventTypeCheckoutSessionCompleted,
+		stripe.EventTypeCheckoutSessionAsyncPaymentSucceeded:
+		var sess stripe.CheckoutSession
+		if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
+			return fmt.Errorf("failed to parse checkout session: %w", err)
+		}
+		// Delayed payment methods complete the session before the money arrives
+		if sess.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid {
+			return p.applyEvent(event, sess.ID, sess.Metadata, func(order *models.Order, tx *gorm.DB) error {
+				if order.Status != models.OrderStatusProcessing || order.PaymentStatus == models.PaymentStatusCompleted {
+					return nil
+				}
+				return awaitDelayedPayment(order, tx)
+			})
+		}
+		var paidOrderId uint64
+		err := p.applyEvent(event, sess.ID, sess.Metadata, func(order *models.Order, tx *gorm.DB) error {
+			if order.Status != models.OrderStatusProcessing || order.PaymentStatus == models.PaymentStatusCompleted {
+				return nil
+			}
+			order.Status = models.OrderStatusCompleted
+			order.PaymentStatus = models.PaymentStatusCompleted
+			if sess.PaymentIntent != nil {
+				order.TransactionId = sess.PaymentIntent.ID
+			}
+			if err := updateOrderStatus(order, tx); err != nil {
+				return err
+			}
+			paidOrderId = order.Id
+			return convertReservations(order, tx)
+		})
+		if err != nil || paidOrderId == 0 {
+			return err
+		}
+
+		// Merchants are paid once the payment is committed, the order settler retries when this fails
+		if err := NewPayoutService().SettleOrder(paidOrderId); err != nil {
+			log.Printf("stripe: failed to settle order %d: %v", paidOrderId, err)
+		}
+		return nil
+
+	case stripe.EventTypeCheckoutSessionExpired:
+		var sess stripe.CheckoutSession
+		if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
+			return fmt.Errorf("failed to parse checkout session: %w", err)
+		}
+		return p.applyEvent(event, sess.ID, sess.Metadata, func(order *models.Order, tx *gorm.DB) error {
+			if order.Status != models.OrderStatusProcessing || order.PaymentStatus == models.PaymentStatusCompleted {
+				return nil
+			}
+			return cancelUnpaidOrder(order, models.StockActorSystem, tx)
+		})
+
+	case stripe.EventTypeCheckoutSessionAsyncPaymentFailed:
+		var sess stripe.CheckoutSession
+		if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
+			return fmt.Errorf("failed to parse checkout session: %w", err)
+		}
+		// The session is complete, the buyer can not retry so the stock goes back
+		return p.applyEvent(event, sess.ID, sess.Metadata, func(order *models.Order, tx *gorm.DB) error {
+			if order.Status != models.OrderStatusProcessing || order.PaymentStatus == models.PaymentStatusCompleted {
+				return nil
+			}
+			return cancelUnpaidOrder(order, models.StockActorSystem, tx)
+		})
+
+	case stripe.EventTypePaymentIntentPaymentFailed:
+		var paymentIntent stripe.PaymentIntent
+		if err := json.Unmarshal(event.Data.Raw, &paymentIntent); err != nil {
+			return fmt.Errorf("failed to parse payment intent: %w", err)
+		}
+		return p.applyEvent(event, "", paymentIntent.Metadata, func(order *models.Order, tx *gorm.DB) error {
+			order.TransactionId = paymentIntent.ID
+			return markPaymentFailed(order, tx)
+		})
+
+	default:
+		log.Printf("stripe: ignoring unhandled event type %s", event.Type)
+		return nil
+	}
+}
+
+// markPaymentFailed flags a failed attempt, the buyer may still retry within the session
+func markPaymentFailed(order *models.Order, tx *gorm.DB) error {
+	if order.Status != models.OrderStatusProcessing || order.PaymentStatus != models.PaymentStatusPending {
+		return nil
+	}
+	order.PaymentStatus = models.PaymentStatusFailed
+	return updateOrderStatus(order, tx)
+}
+
+// applyEvent locks the order referenced by the event and applies the transition.
+// The event is recorded in the same transaction so replays become no-ops.
+// Transitions must leave the order untouched if it is no longer in the expected state.
+func (p *PaymentService) applyEvent(event *stripe.Event, sessionId string, metadata map[string]string, transition func(order *models.Order, tx *gorm.DB) error) error {
+	orderId, err := p.findOrderId(sessionId, metadata)
+	if errors.Is(err, errUnknownOrder) {
+		log.Printf("stripe: ignoring event %s: %v", event.ID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tx := storage.StorageInstance.BeginTransaction()
+
+	recorded, err := storage.StorageInstance.Stripe.CreateEvent(&models.StripeEvent{
+		Id:   event.ID,
+		Type: string(event.Type),
+	}, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record stripe event: %w", err)
+	}
+	if !recorded {
+		tx.Rollback()
+		log.Printf("stripe: ignoring replayed event %s", event.ID)
+		return nil
+	}
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(orderId, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if err := transition(order, tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// findOrderId resolves the order through the checkout session, falling back to the orderId metadata
+func (p *PaymentService) findOrderId(sessionId string, metadata map[string]string) (uint64, error) {
+	if sessionId != "" {
+		order, err := storage.StorageInstance.Order.GetOrderByCheckoutSessionId(sessionId, nil)
+		if err == nil {
+			return order.Id, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("failed to get order: %w", err)
+		}
+	}
+
+	rawOrderId, ok := metadata["orderId"]
+	if !ok {
+		return 0, errUnknownOrder
+	}
+	orderId, err := strconv.ParseUint(rawOrderId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid orderId metadata %q: %w", rawOrderId, err)
+	}
+	return orderId, nil
+}
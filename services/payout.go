@@ -0,0 +1,390 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"product/configs"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type PayoutService struct{}
+
+func NewPayoutService() *PayoutService {
+	return &PayoutService{}
+}
+
+// OnboardMerchant links the merchant to a Stripe Connect account, creating one on first use,
+// and returns the link where the merchant completes onboarding.
+func (p *PayoutService) OnboardMerchant(req *pb.OnboardMerchantRequest) (*pb.OnboardMerchantResponse, error) {
+	merchant, err := storage.StorageInstance.Merchant.GetMerchant(req.GetMerchantId(), nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		merchant = &models.Merchant{Id: req.GetMerchantId()}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if merchant.StripeAccountId == "" {
+		account, err := NewStripeService().CreateConnectedAccount(req.GetEmail())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create connected account: %w", err)
+		}
+		merchant.StripeAccountId = account.ID
+
+		merchant, err = storage.StorageInstance.Merchant.SaveMerchant(merchant, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	link, err := NewStripeService().CreateOnboardingLink(merchant.StripeAccountId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create onboarding link: %w", err)
+	}
+
+	return &pb.OnboardMerchantResponse{
+		Merchant:      storage.MerchantDBToGrpc(merchant, configs.PLATFORM_COMMISSION_BPS),
+		OnboardingUrl: link.URL,
+	}, nil
+}
+
+// SetMerchantCommission overrides the platform commission for a merchant's future sales
+func (p *PayoutService) SetMerchantCommission(req *pb.SetMerchantCommissionRequest) (*pb.Merchant, error) {
+	if req.GetCommissionBps() > 10000 {
+		return nil, status.Error(codes.InvalidArgument, "commission can not exceed 10000 basis points")
+	}
+
+	merchant, err := storage.StorageInstance.Merchant.GetMerchant(req.GetMerchantId(), nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		merchant = &models.Merchant{Id: req.GetMerchantId()}
+	} else if err != nil {
+		return nil, err
+	}
+
+	commissionBps := req.GetCommissionBps()
+	merchant.CommissionBps = &commissionBps
+	merchant, err = storage.StorageInstance.Merchant.SaveMerchant(merchant, nil)
+	if err != nil {
+		return nil, err
+	}
+	return storage.MerchantDBToGrpc(merchant, configs.PLATFORM_COMMISSION_BPS), nil
+}
+
+func (p *PayoutService) GetOrderLedger(orderId uint64) (*pb.GetOrderLedgerResponse, error) {
+	entries, err := storage.StorageInstance.Ledger.ListByOrderId(orderId, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetOrderLedgerResponse{
+		Entries: storage.LedgerEntriesDBToGrpc(entries),
+	}, nil
+}
+
+// CommissionBps returns the platform commission that applies to sales of the product.
+// The merchant's override comes first, then the lowest commission of the product's categories, then the default.
+func (p *PayoutService) CommissionBps(product *models.Product, tx *gorm.DB) (uint32, error) {
+	merchant, err := storage.StorageInstance.Merchant.GetMerchant(product.MerchantId, tx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	if err == nil && merchant.CommissionBps != nil {
+		return *merchant.CommissionBps, nil
+	}
+
+	var commissionBps *uint32
+	for _, category := range product.Categories {
+		if category.CommissionBps != nil && (commissionBps == nil || *category.CommissionBps < *commissionBps) {
+			commissionBps = category.CommissionBps
+		}
+	}
+	if commissionBps == nil {
+		return configs.PLATFORM_COMMISSION_BPS, nil
+	}
+	return *commissionBps, nil
+}
+
+// SettleOrder splits a paid order between the platform and its merchants.
+// Each merchant's share is transferred to their connected account, merchants that
+// have not onboarded yet keep an open payable in the ledger and the order stays unsettled,
+// so the order settler pays them once they have connected an account.
+// Ledger entries and transfers are keyed on the order and its sub-orders, so settling an order
+// that was only partly settled before records and transfers only what is missing.
+func (p *PayoutService) SettleOrder(orderId uint64) error {
+	// Read from the primary, the payment may have been committed a moment ago
+	tx := storage.StorageInstance.BeginTransaction()
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(orderId, tx)
+	tx.Rollback()
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.SettledAt != nil {
+		return nil
+	}
+	if order.TransactionId == "" {
+		return fmt.Errorf("order %d has no recorded payment", order.Id)
+	}
+
+	chargeId, err := p.chargeId(order)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, item := range order.OrderItems {
+		total += lineAmount(item.PriceMinor, item.Quantity)
+	}
+	entries := []*models.LedgerEntry{{
+		OrderId:        order.Id,
+		Type:           models.LedgerEntryCharge,
+		Amount:         total,
+		StripeObjectId: chargeId,
+		Key:            ledgerKey("charge-order-%d", order.Id),
+	}}
+	if err := storage.StorageInstance.Ledger.CreateEntries(withCurrency(entries, order.Currency), nil); err != nil {
+		return fmt.Errorf("failed to record charge: %w", err)
+	}
+
+	settled := true
+	for _, subOrder := range order.SubOrders {
+		paid, err := p.settleSubOrder(order, subOrder, chargeId)
+		if err != nil {
+			return err
+		}
+		settled = settled && paid
+	}
+	if !settled {
+		return nil
+	}
+
+	if err := storage.StorageInstance.Order.MarkSettled(order.Id, time.Now(), nil); err != nil {
+		return fmt.Errorf("failed to mark order settled: %w", err)
+	}
+	return nil
+}
+
+// chargeId is the charge that paid the order, recorded in the ledger by an earlier settlement or asked from Stripe
+func (p *PayoutService) chargeId(order *models.Order) (string, error) {
+	ledger, err := storage.StorageInstance.Ledger.ListByOrderId(order.Id, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ledger: %w", err)
+	}
+	for _, entry := range ledger {
+		if entry.Type == models.LedgerEntryCharge && entry.StripeObjectId != "" {
+			return entry.StripeObjectId, nil
+		}
+	}
+
+	chargeId, err := NewStripeService().GetLatestChargeId(order.TransactionId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get charge: %w", err)
+	}
+	return chargeId, nil
+}
+
+// settleSubOrder records the platform fee and the merchant's share of a sub-order, and transfers the share.
+// The order stays locked meanwhile so a refund can not take the share back while it is being transferred,
+// what a refund took back before is not transferred.
+// Reports whether the merchant has got everything it is owed, it has not while it has no connected account.
+func (p *PayoutService) settleSubOrder(order *models.Order, subOrder models.Order, chargeId string) (bool, error) {
+	tx := storage.StorageInstance.BeginTransaction()
+
+	if _, err := storage.StorageInstance.Order.GetOrderWithLock(order.Id, tx); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get order: %w", err)
+	}
+	ledger, err := storage.StorageInstance.Ledger.ListByOrderId(order.Id, tx)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get ledger: %w", err)
+	}
+
+	var reversed int64
+	for _, entry := range ledger {
+		if entry.SubOrderId != subOrder.Id {
+			continue
+		}
+		switch entry.Type {
+		case models.LedgerEntryTransfer:
+			// Settled before
+			tx.Rollback()
+			return true, nil
+		case models.LedgerEntryMerchantPayableReversal:
+			reversed += entry.Amount
+		}
+	}
+
+	var gross, fee int64
+	for _, item := range order.OrderItems {
+		if item.SubOrderId != nil && *item.SubOrderId == subOrder.Id {
+			amount := lineAmount(item.PriceMinor, item.Quantity)
+			gross += amount
+			fee += lineCommission(amount, item.CommissionBps)
+		}
+	}
+	payable := gross - fee
+
+	entries := []*models.LedgerEntry{
+		{OrderId: order.Id, SubOrderId: subOrder.Id, MerchantId: subOrder.MerchantId, Type: models.LedgerEntryPlatformFee, Amount: fee,
+			Key: ledgerKey("platform-fee-suborder-%d", subOrder.Id)},
+		{OrderId: order.Id, SubOrderId: subOrder.Id, MerchantId: subOrder.MerchantId, Type: models.LedgerEntryMerchantPayable, Amount: payable,
+			Key: ledgerKey("merchant-payable-suborder-%d", subOrder.Id)},
+	}
+
+	merchant, err := storage.StorageInstance.Merchant.GetMerchant(subOrder.MerchantId, tx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get merchant: %w", err)
+	}
+	paid := true
+	if share := payable - reversed; share <= 0 {
+		log.Printf("payout: nothing to transfer to merchant %d for sub-order %d, it was refunded", subOrder.MerchantId, subOrder.Id)
+	} else if merchant == nil || merchant.StripeAccountId == "" {
+		log.Printf("payout: holding %d %s for merchant %d until they connect a Stripe account", share, order.Currency, subOrder.MerchantId)
+		paid = false
+	} else {
+		// Keyed on the sub-order so settling again can not pay the merchant twice
+		result, err := NewStripeService().TransferToMerchant(merchant.StripeAccountId, share, order.Currency, transferGroup(order.Id), chargeId,
+			fmt.Sprintf("transfer-suborder-%d", subOrder.Id))
+		if err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to transfer to merchant %d: %w", subOrder.MerchantId, err)
+		}
+		entries = append(entries, &models.LedgerEntry{
+			OrderId:        order.Id,
+			SubOrderId:     subOrder.Id,
+			MerchantId:     subOrder.MerchantId,
+			Type:           models.LedgerEntryTransfer,
+			Amount:         result.Amount,
+			StripeObjectId: result.ID,
+			Key:            ledgerKey("transfer-suborder-%d", subOrder.Id),
+		})
+	}
+
+	if err := storage.StorageInstance.Ledger.CreateEntries(withCurrency(entries, order.Currency), tx); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to record payout: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return paid, nil
+}
+
+// ReverseRefund records a refund in the ledger and takes the refunded share back from each merchant.
+// The platform refunds its commission on the refunded lines.
+func (p *PayoutService) ReverseRefund(order *models.Order, refund *models.Refund, tx *gorm.DB) error {
+	ledger, err := storage.StorageInstance.Ledger.ListByOrderId(order.Id, tx)
+	if err != nil {
+		return fmt.Errorf("failed to get ledger: %w", err)
+	}
+	transfers := make(map[uint64]*models.LedgerEntry)
+	for _, entry := range ledger {
+		if entry.Type == models.LedgerEntryTransfer {
+			transfers[entry.SubOrderId] = entry
+		}
+	}
+
+	orderItems := make(map[models.LineKey]models.OrderItem, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		orderItems[item.Key()] = item
+	}
+
+	var total int64
+	gross := make(map[uint64]int64) // sub-order ID -> refunded amount
+	fees := make(map[uint64]int64)  // sub-order ID -> refunded commission
+	var subOrderIds []uint64
+	for _, refundItem := range refund.Items {
+		item := orderItems[refundItem.Key()]
+		amount := lineAmount(item.PriceMinor, refundItem.Quantity)
+		total += amount
+
+		var subOrderId uint64
+		if item.SubOrderId != nil {
+			subOrderId = *item.SubOrderId
+		}
+		if _, ok := gross[subOrderId]; !ok {
+			subOrderIds = append(subOrderIds, subOrderId)
+		}
+		gross[subOrderId] += amount
+		fees[subOrderId] += lineCommission(amount, item.CommissionBps)
+	}
+
+	entries := []*models.LedgerEntry{{
+		OrderId:        order.Id,
+		Type:           models.LedgerEntryRefund,
+		Amount:         total,
+		StripeObjectId: refund.StripeRefundId,
+	}}
+
+	merchants := make(map[uint64]uint64, len(order.SubOrders))
+	for _, subOrder := range order.SubOrders {
+		merchants[subOrder.Id] = subOrder.MerchantId
+	}
+
+	for _, subOrderId := range subOrderIds {
+		merchantId := merchants[subOrderId]
+		share := gross[subOrderId] - fees[subOrderId]
+		entries = append(entries, &models.LedgerEntry{
+			OrderId: order.Id, SubOrderId: subOrderId, MerchantId: merchantId, Type: models.LedgerEntryPlatformFeeRefund, Amount: fees[subOrderId],
+		})
+
+		transferEntry, ok := transfers[subOrderId]
+		if !ok || share <= 0 {
+			entries = append(entries, &models.LedgerEntry{
+				OrderId: order.Id, SubOrderId: subOrderId, MerchantId: merchantId, Type: models.LedgerEntryMerchantPayableReversal, Amount: share,
+			})
+			continue
+		}
+
+		reversal, err := NewStripeService().ReverseTransfer(transferEntry.StripeObjectId, share,
+			fmt.Sprintf("reversal-refund-%d-suborder-%d", refund.Id, subOrderId))
+		if err != nil {
+			return fmt.Errorf("failed to reverse transfer to merchant %d: %w", merchantId, err)
+		}
+		entries = append(entries, &models.LedgerEntry{
+			OrderId:        order.Id,
+			SubOrderId:     subOrderId,
+			MerchantId:     merchantId,
+			Type:           models.LedgerEntryTransferReversal,
+			Amount:         reversal.Amount,
+			StripeObjectId: reversal.ID,
+		})
+	}
+
+	return storage.StorageInstance.Ledger.CreateEntries(withCurrency(entries, order.Currency), tx)
+}
+
+// ledgerKey identifies a ledger entry that must only be recorded once
+func ledgerKey(format string, args ...any) *string {
+	key := fmt.Sprintf(format, args...)
+	return &key
+}
+
+// transferGroup ties the charge and the merchant transfers of an order together in Stripe
+func transferGroup(orderId uint64) string {
+	return fmt.Sprintf("order_%d", orderId)
+}
+
+// lineAmount is the price of an order line in minor units
+func lineAmount(priceMinor int64, quantity uint64) int64 {
+	return priceMinor * int64(quantity)
+}
+
+// lineCommission is the platform's cut of an amount in minor units, rounded half up
+func lineCommission(amount int64, commissionBps uint32) int64 {
+	return (amount*int64(commissionBps) + 5000) / 10000
+}
+
+func withCurrency(entries []*models.LedgerEntry, currency string) []*models.LedgerEntry {
+	for _, entry := range entries {
+		entry.Currency = currency
+	}
+	return entries
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/json"
+	"product/configs"
+	"product/models"
+	"product/storage"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	"gorm.io/gorm"
+)
+
+// connectedMerchants serves merchant settings from memory
+type connectedMerchants struct {
+	storage.MerchantInterface
+	merchants map[uint64]*models.Merchant
+}
+
+func (c connectedMerchants) GetMerchant(id uint64, tx *gorm.DB) (*models.Merchant, error) {
+	merchant, ok := c.merchants[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return merchant, nil
+}
+
+// recordedEvents remembers the Stripe events that were handled
+type recordedEvents struct {
+	storage.StripeEventInterface
+	seen map[string]bool
+}
+
+func (r *recordedEvents) CreateEvent(event *models.StripeEvent, tx *gorm.DB) (bool, error) {
+	if r.seen[event.Id] {
+		return false, nil
+	}
+	r.seen[event.Id] = true
+	return true, nil
+}
+
+// recordedStock keeps stock movements in memory
+type recordedStock struct {
+	storage.StockLedgerInterface
+	movements []*models.StockMovement
+}
+
+func (r *recordedStock) CreateMovements(movements []*models.StockMovement, tx *gorm.DB) error {
+	r.movements = append(r.movements, movements...)
+	return nil
+}
+
+// useSettlementStorage stores the given order, merchant 9 is connected to Stripe
+func useSettlementStorage(t *testing.T, order *models.Order) (*savedOrders, *recordedLedger) {
+	t.Helper()
+	store, _ := useTestStorage(t)
+	orders := &savedOrders{orders: map[uint64]*models.Order{order.Id: order}}
+	store.Order = orders
+	ledger := &recordedLedger{}
+	store.Ledger = ledger
+	store.Merchant = connectedMerchants{merchants: map[uint64]*models.Merchant{9: {Id: 9, StripeAccountId: "acct_9"}}}
+	store.Stripe = &recordedEvents{seen: map[string]bool{}}
+	store.Reservation = &heldReservations{}
+	store.StockLedger = &recordedStock{}
+	return orders, ledger
+}
+
+func TestPaidOrderIsSettled(t *testing.T) {
+	order := paidOrder()
+	order.Status = models.OrderStatusProcessing
+	order.PaymentStatus = models.PaymentStatusPending
+	order.TransactionId = ""
+	order.CheckoutSessionId = "cs_1"
+	orders, ledger := useSettlementStorage(t, order)
+	fake := useTestStripe(t)
+
+	raw, err := json.Marshal(map[string]any{"id": "cs_1", "payment_status": "paid", "payment_intent": "pi_1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := &stripe.Event{ID: "evt_1", Type: stripe.EventTypeCheckoutSessionCompleted, Data: &stripe.EventData{Raw: raw}}
+	if err := NewPaymentService().HandleStripeEvent(event); err != nil {
+		t.Fatalf("HandleStripeEvent: %v", err)
+	}
+
+	if orders.orders[1].SettledAt == nil {
+		t.Error("paid order was not settled")
+	}
+	transfers := fake.callsTo("/v1/transfers")
+	if len(transfers) != 1 || transfers[0].idempotencyKey != "transfer-suborder-2" {
+		t.Fatalf("transfers %+v, want one keyed on sub-order 2", transfers)
+	}
+	// 2 mugs of 5.00 with a 10% commission
+	for key, amount := range map[string]int64{
+		"charge-order-1":              1000,
+		"platform-fee-suborder-2":     100,
+		"merchant-payable-suborder-2": 900,
+		"transfer-suborder-2":         900,
+	} {
+		entry := ledger.find(key)
+		if entry == nil {
+			t.Errorf("ledger has no %s entry", key)
+			continue
+		}
+		if entry.Amount != amount || entry.Currency != "SGD" {
+			t.Errorf("%s entry is %d %s, want %d SGD", key, entry.Amount, entry.Currency, amount)
+		}
+	}
+
+	// Settling again, e.g. when marking the order settled failed, pays nobody twice
+	entries := len(ledger.entries)
+	orders.orders[1].SettledAt = nil
+	if err := NewPayoutService().SettleOrder(1); err != nil {
+		t.Fatalf("settling again: %v", err)
+	}
+	if len(fake.callsTo("/v1/transfers")) != 1 {
+		t.Error("settling again transferred to the merchant again")
+	}
+	if len(ledger.entries) != entries {
+		t.Errorf("settling again recorded %d more ledger entries", len(ledger.entries)-entries)
+	}
+}
+
+func TestSettleOrderHoldsBackRefundedShare(t *testing.T) {
+	orders, ledger := useSettlementStorage(t, paidOrder())
+	fake := useTestStripe(t)
+	// A mug was refunded before the merchant was paid
+	ledger.entries = append(ledger.entries, &models.LedgerEntry{
+		OrderId: 1, SubOrderId: 2, MerchantId: 9, Type: models.LedgerEntryMerchantPayableReversal, Amount: 450,
+	})
+
+	if err := NewOrderSettler(time.Minute).Settle(); err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+	if orders.orders[1].SettledAt == nil {
+		t.Error("order was not settled")
+	}
+	if len(fake.callsTo("/v1/transfers")) != 1 {
+		t.Fatalf("made %d transfers, want 1", len(fake.callsTo("/v1/transfers")))
+	}
+	if entry := ledger.find("transfer-suborder-2"); entry == nil || entry.Amount != 450 {
+		t.Errorf("transfer entry %+v, want 450 for the mug that was kept", entry)
+	}
+}
+
+func TestSettlerPaysMerchantOnceOnboarded(t *testing.T) {
+	orders, ledger := useSettlementStorage(t, paidOrder())
+	merchant := &models.Merchant{Id: 9}
+	storage.StorageInstance.Merchant = connectedMerchants{merchants: map[uint64]*models.Merchant{9: merchant}}
+	fake := useTestStripe(t)
+
+	if err := NewPayoutService().SettleOrder(1); err != nil {
+		t.Fatalf("SettleOrder: %v", err)
+	}
+	if orders.orders[1].SettledAt != nil {
+		t.Error("order was settled while its merchant has no connected account")
+	}
+	if entry := ledger.find("merchant-payable-suborder-2"); entry == nil || entry.Amount != 900 {
+		t.Errorf("payable entry %+v, want 900 held for the merchant", entry)
+	}
+
+	merchant.StripeAccountId = "acct_9"
+	if err := NewOrderSettler(time.Minute).Settle(); err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+	if orders.orders[1].SettledAt == nil {
+		t.Error("order was not settled once its merchant onboarded")
+	}
+	if entry := ledger.find("transfer-suborder-2"); entry == nil || entry.Amount != 900 {
+		t.Errorf("transfer entry %+v, want 900", entry)
+	}
+	// The charge recorded by the first attempt is reused
+	if calls := fake.callsTo("/v1/payment_intents/pi_1"); len(calls) != 1 {
+		t.Errorf("looked up the charge %d times, want 1", len(calls))
+	}
+}
+
+func TestCommissionPrefersMerchantThenCategory(t *testing.T) {
+	store, _ := useTestStorage(t)
+	low, high, override := uint32(500), uint32(1500), uint32(200)
+	store.Merchant = connectedMerchants{merchants: map[uint64]*models.Merchant{
+		9:  {Id: 9},
+		10: {Id: 10, CommissionBps: &override},
+	}}
+
+	tests := []struct {
+		name    string
+		product *models.Product
+		want    uint32
+	}{
+		{"default", &models.Product{MerchantId: 9, Categories: []models.Category{{Id: 1}}}, configs.PLATFORM_COMMISSION_BPS},
+		{"lowest category", &models.Product{MerchantId: 9, Categories: []models.Category{{Id: 1, CommissionBps: &high}, {Id: 2, CommissionBps: &low}}}, low},
+		{"merchant override", &models.Product{MerchantId: 10, Categories: []models.Category{{Id: 2, CommissionBps: &low}}}, override},
+	}
+	for _, tt := range tests {
+		got, err := NewPayoutService().CommissionBps(tt.product, nil)
+		if err != nil {
+			t.Fatalf("%s: CommissionBps: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: commission %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
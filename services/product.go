@@ -2,11 +2,27 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"product/configs"
 	"product/models"
 	pb "product/proto"
 	"product/storage"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/stripe/stripe-go/v81"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Products listed on a page when the client does not ask for a number, and the most it can ask for
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
 )
 
 type ProductService struct {
@@ -16,16 +32,45 @@ func NewProductService() *ProductService {
 	return &ProductService{}
 }
 
-func (p *ProductService) GetProduct(id uint64) (*pb.Product, error) {
+// GetProduct gets a product buyers can see, merchants also see their own drafts, scheduled and archived products.
+// merchantId is 0 for callers who are not merchants.
+func (p *ProductService) GetProduct(id, merchantId uint64) (*pb.Product, error) {
 	product_db, err := storage.StorageInstance.Product.Get(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", id)
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if !product_db.IsPublished(time.Now()) && (merchantId == 0 || product_db.MerchantId != merchantId) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", id)
 	}
 	return storage.DBToGrpc(product_db), nil
 }
 
-func (p *ProductService) CreateProduct(product *pb.Product) (*pb.Product, error) {
-	stripeProduct, err := NewStripeService().CreateNewProduct(product.Name, product.Price)
+func (p *ProductService) CreateProduct(product *pb.Product, categoryIds []uint64) (*pb.Product, error) {
+	if err := normalizePrice(product, configs.DEFAULT_CURRENCY); err != nil {
+		return nil, err
+	}
+
+	if product.Status == "" {
+		product.Status = string(models.ProductStatusPublished)
+	}
+	if err := normalizeStatus(product, ""); err != nil {
+		return nil, err
+	}
+
+	prices, err := normalizePrices(product.Prices, product.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, attributes, err := normalizeSpecifications(product.Specifications)
+	if err != nil {
+		return nil, err
+	}
+
+	stripeProduct, err := NewStripeService().CreateNewProduct(product.Name, product.PriceMinor, product.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -33,38 +78,321 @@ func (p *ProductService) CreateProduct(product *pb.Product) (*pb.Product, error)
 	product.StripeProductId = stripeProduct.ID
 	product.StripePriceId = stripeProduct.DefaultPrice.ID
 
-	product_db, err := storage.StorageInstance.Product.CreateProduct(storage.GrpcToDB(product))
+	// Additional prices and specifications are saved together with the product
+	productDB := storage.GrpcToDB(product)
+	productDB.Specifications = specs
+	productDB.Attributes = attributes
+	for _, price := range prices {
+		if price.PriceMinor == 0 {
+			continue
+		}
+		stripePrice, err := NewStripeService().CreateProductPrice(stripeProduct.ID, price.PriceMinor, price.Currency)
+		if err != nil {
+			return nil, err
+		}
+		productDB.Prices = append(productDB.Prices, models.ProductPrice{
+			Currency:      price.Currency,
+			PriceMinor:    price.PriceMinor,
+			StripePriceId: stripePrice.ID,
+		})
+	}
+
+	// The initial stock is the first entry of the product's stock ledger
+	tx := storage.StorageInstance.BeginTransaction()
+	product_db, err := storage.StorageInstance.Product.CreateProduct(productDB, tx)
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
+	if product_db.Inventory > 0 {
+		source := models.StockMovement{Type: models.StockMovementImport, Actor: models.UserStockActor(product_db.MerchantId)}
+		line := models.LineKey{ProductId: product_db.Id}
+		if err := recordStock([]*models.StockMovement{stockMovement(source, line, int64(product_db.Inventory), product_db.Inventory)}, tx); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if len(categoryIds) > 0 {
+		product_db.Categories, err = NewCategoryService().setProductCategories(product_db.Id, categoryIds)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return storage.DBToGrpc(product_db), nil
 }
 
+// UpdateProduct updates a product of the merchant set on updatedProduct, which can not be changed
 func (p *ProductService) UpdateProduct(updatedProduct *pb.Product) (*pb.Product, error) {
-	existingProduct, err := storage.StorageInstance.Product.Get(updatedProduct.Id, nil)
+	existingProduct, err := getMerchantProduct(updatedProduct.Id, updatedProduct.MerchantId)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := normalizePrice(updatedProduct, existingProduct.Currency); err != nil {
+		return nil, err
+	}
+
+	var existingPublishAt string
+	if existingProduct.PublishAt != nil {
+		existingPublishAt = existingProduct.PublishAt.Format(time.RFC3339)
+	}
+	if err := normalizeStatus(updatedProduct, existingPublishAt); err != nil {
+		return nil, err
+	}
+
+	specs, attributes := existingProduct.Specifications, existingProduct.Attributes
+	if updatedProduct.Specifications != nil {
+		if specs, attributes, err = normalizeSpecifications(updatedProduct.Specifications); err != nil {
+			return nil, err
+		}
+	}
+
 	// check if the price has changed
 	var stripeProduct *stripe.Product
-	if updatedProduct.Price != 0 && updatedProduct.Price != existingProduct.Price {
-		stripeProduct, err = NewStripeService().UpdateProductPrice(existingProduct.StripeProductId, existingProduct.StripePriceId, updatedProduct.Price)
+	if (updatedProduct.PriceMinor != 0 && updatedProduct.PriceMinor != existingProduct.PriceMinor) || updatedProduct.Currency != existingProduct.Currency {
+		if updatedProduct.PriceMinor == 0 {
+			updatedProduct.PriceMinor = existingProduct.PriceMinor
+		}
+		stripeProduct, err = NewStripeService().UpdateProductPrice(existingProduct.StripeProductId, existingProduct.StripePriceId, updatedProduct.PriceMinor, updatedProduct.Currency)
 		if err != nil {
 			return nil, err
 		}
 		updatedProduct.StripePriceId = stripeProduct.DefaultPrice.ID
 	}
 
-	product_db, err := storage.StorageInstance.Product.Update(storage.GrpcToDB(updatedProduct), nil)
+	prices, err := normalizePrices(updatedProduct.Prices, updatedProduct.Currency)
+	if err != nil {
+		return nil, err
+	}
+	updatedPrices, err := p.updatePrices(existingProduct, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new inventory is set through the stock ledger, 0 leaves it unchanged like the other fields
+	inventory := updatedProduct.Inventory
+	updatedProduct.Inventory = 0
+	tx := storage.StorageInstance.BeginTransaction()
+	product_db, err := storage.StorageInstance.Product.Update(storage.GrpcToDB(updatedProduct), tx)
 	if err != nil {
+		tx.Rollback()
 		return updatedProduct, err
 	}
+	product_db.Inventory = existingProduct.Inventory
+	if inventory != 0 {
+		if err := adjustStock(models.LineKey{ProductId: product_db.Id}, inventory, models.UserStockActor(updatedProduct.MerchantId), tx); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		product_db.Inventory = inventory
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if updatedProduct.Specifications != nil {
+		if err := storage.StorageInstance.Product.SaveSpecifications(product_db.Id, specs, attributes, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	product_db.Prices = updatedPrices
+	product_db.Specifications = specs
+	product_db.Attributes = attributes
+	product_db.Options = existingProduct.Options
+	product_db.Variants = existingProduct.Variants
+	product_db.Categories = existingProduct.Categories
+	product_db.CreatedAt = existingProduct.CreatedAt
+	if product_db.Status == "" {
+		product_db.Status = existingProduct.Status
+	}
+	if product_db.PublishAt == nil {
+		product_db.PublishAt = existingProduct.PublishAt
+	}
 	return storage.DBToGrpc(product_db), nil
 }
 
-func (p *ProductService) DeleteProduct(id uint64) error {
-	existingProduct, err := storage.StorageInstance.Product.Get(id, nil)
+// updatePrices adds, replaces and removes the product's additional prices.
+// Returns the prices the product is sold at afterwards.
+func (p *ProductService) updatePrices(existingProduct *models.Product, prices []*pb.ProductPrice) ([]models.ProductPrice, error) {
+	current := make(map[string]models.ProductPrice, len(existingProduct.Prices))
+	for _, price := range existingProduct.Prices {
+		current[price.Currency] = price
+	}
+
+	for _, price := range prices {
+		if price.PriceMinor == 0 {
+			if err := storage.StorageInstance.Product.DeletePrice(existingProduct.Id, price.Currency, nil); err != nil {
+				return nil, err
+			}
+			delete(current, price.Currency)
+			continue
+		}
+		if existing, ok := current[price.Currency]; ok && existing.PriceMinor == price.PriceMinor {
+			continue
+		}
+
+		stripePrice, err := NewStripeService().CreateProductPrice(existingProduct.StripeProductId, price.PriceMinor, price.Currency)
+		if err != nil {
+			return nil, err
+		}
+		productPrice := models.ProductPrice{
+			ProductId:     existingProduct.Id,
+			Currency:      price.Currency,
+			PriceMinor:    price.PriceMinor,
+			StripePriceId: stripePrice.ID,
+		}
+		if err := storage.StorageInstance.Product.SavePrice(&productPrice, nil); err != nil {
+			return nil, err
+		}
+		current[price.Currency] = productPrice
+	}
+
+	updatedPrices := make([]models.ProductPrice, 0, len(current))
+	for _, price := range current {
+		updatedPrices = append(updatedPrices, price)
+	}
+	sort.Slice(updatedPrices, func(i, j int) bool {
+		return updatedPrices[i].Currency < updatedPrices[j].Currency
+	})
+	return updatedPrices, nil
+}
+
+// CheckMerchantProduct makes sure the product exists and is sold by the merchant
+func (p *ProductService) CheckMerchantProduct(productId, merchantId uint64) error {
+	_, err := getMerchantProduct(productId, merchantId)
+	return err
+}
+
+// getMerchantProduct gets the product, which must be sold by the merchant
+func getMerchantProduct(productId, merchantId uint64) (*models.Product, error) {
+	product, err := storage.StorageInstance.Product.Get(productId, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", productId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product.MerchantId != merchantId {
+		return nil, status.Error(codes.PermissionDenied, "product does not belong to the merchant")
+	}
+	return product, nil
+}
+
+// normalizePrice validates the currency and fills in the minor unit price
+// from the deprecated float price that older clients still send.
+func normalizePrice(product *pb.Product, defaultCurrency string) error {
+	if product.Currency == "" {
+		product.Currency = defaultCurrency
+	}
+	product.Currency = strings.ToUpper(product.Currency)
+	if len(product.Currency) != 3 {
+		return status.Errorf(codes.InvalidArgument, "invalid currency %q", product.Currency)
+	}
+
+	if product.PriceMinor == 0 && product.Price != 0 {
+		product.PriceMinor = models.ToMinorUnits(product.Price, product.Currency)
+	}
+	if product.PriceMinor < 0 {
+		return status.Error(codes.InvalidArgument, "price can not be negative")
+	}
+	return nil
+}
+
+// normalizeStatus validates the lifecycle state of a product, an empty status is left unchanged.
+// Scheduled products need a publish time, either in the product or the one they already had.
+func normalizeStatus(product *pb.Product, existingPublishAt string) error {
+	product.Status = strings.ToLower(strings.TrimSpace(product.Status))
+	if product.Status != "" && !slices.Contains(models.ProductStatuses, models.ProductStatus(product.Status)) {
+		return status.Errorf(codes.InvalidArgument, "unknown product status %q", product.Status)
+	}
+
+	if product.PublishAt != "" {
+		if _, err := time.Parse(time.RFC3339, product.PublishAt); err != nil {
+			return status.Errorf(codes.InvalidArgument, "publish_at must be an RFC 3339 timestamp: %v", err)
+		}
+	}
+	if product.Status == string(models.ProductStatusScheduled) && product.PublishAt == "" && existingPublishAt == "" {
+		return status.Error(codes.InvalidArgument, "publish_at is required for scheduled products")
+	}
+	return nil
+}
+
+// normalizeSpecifications validates the specifications of a product and converts the typed attribute values
+func normalizeSpecifications(specs *pb.ProductSpecifications) (models.ProductSpecifications, []models.ProductAttribute, error) {
+	if specs == nil {
+		return models.ProductSpecifications{}, nil, nil
+	}
+
+	attributes := make([]models.ProductAttribute, 0, len(specs.GetAttributes()))
+	seen := make(map[string]bool, len(specs.GetAttributes()))
+	for _, attribute := range specs.GetAttributes() {
+		key := strings.ToLower(strings.TrimSpace(attribute.GetKey()))
+		if key == "" {
+			return models.ProductSpecifications{}, nil, status.Error(codes.InvalidArgument, "attribute key is required")
+		}
+		if key == "brand" || key == "material" {
+			return models.ProductSpecifications{}, nil, status.Errorf(codes.InvalidArgument, "set %s on the specifications instead of as an attribute", key)
+		}
+		if seen[key] {
+			return models.ProductSpecifications{}, nil, status.Errorf(codes.InvalidArgument, "attribute %s is listed more than once", key)
+		}
+		seen[key] = true
+
+		productAttribute := models.ProductAttribute{Key: key, Type: models.AttributeType(strings.ToLower(attribute.GetType()))}
+		switch productAttribute.Type {
+		case "", models.AttributeTypeString:
+			productAttribute.Type = models.AttributeTypeString
+			productAttribute.Value = strings.TrimSpace(attribute.GetStringValue())
+		case models.AttributeTypeNumber:
+			number := attribute.GetNumberValue()
+			productAttribute.Value = strconv.FormatFloat(number, 'f', -1, 64)
+			productAttribute.NumberValue = &number
+		case models.AttributeTypeBoolean:
+			productAttribute.Value = strconv.FormatBool(attribute.GetBoolValue())
+		default:
+			return models.ProductSpecifications{}, nil, status.Errorf(codes.InvalidArgument, "attribute %s has unknown type %q", key, attribute.GetType())
+		}
+		attributes = append(attributes, productAttribute)
+	}
+
+	return models.ProductSpecifications{
+		Brand:       strings.TrimSpace(specs.GetBrand()),
+		Material:    strings.TrimSpace(specs.GetMaterial()),
+		WeightGrams: specs.GetWeightGrams(),
+		LengthMm:    specs.GetLengthMm(),
+		WidthMm:     specs.GetWidthMm(),
+		HeightMm:    specs.GetHeightMm(),
+	}, attributes, nil
+}
+
+// normalizePrices validates the additional prices of a product, which must not repeat the base currency
+func normalizePrices(prices []*pb.ProductPrice, baseCurrency string) ([]*pb.ProductPrice, error) {
+	seen := make(map[string]bool, len(prices))
+	for _, price := range prices {
+		price.Currency = strings.ToUpper(price.Currency)
+		if len(price.Currency) != 3 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid currency %q", price.Currency)
+		}
+		if price.Currency == baseCurrency {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is the base currency, set price_minor instead", price.Currency)
+		}
+		if seen[price.Currency] {
+			return nil, status.Errorf(codes.InvalidArgument, "currency %s is listed more than once", price.Currency)
+		}
+		seen[price.Currency] = true
+		if price.PriceMinor < 0 {
+			return nil, status.Error(codes.InvalidArgument, "price can not be negative")
+		}
+	}
+	return prices, nil
+}
+
+func (p *ProductService) DeleteProduct(id, merchantId uint64) error {
+	existingProduct, err := getMerchantProduct(id, merchantId)
 	if err != nil {
 		return err
 	}
@@ -72,38 +400,285 @@ func (p *ProductService) DeleteProduct(id uint64) error {
 	if existingProduct.IsDeleted {
 		return nil
 	}
-	_, err = storage.StorageInstance.Product.Update(&models.Product{Id: id, IsDeleted: true}, nil)
+	return storage.StorageInstance.Product.SoftDelete(id, nil)
+}
+
+// RestoreProduct brings back a deleted product of the merchant that was not purged yet
+func (p *ProductService) RestoreProduct(req *pb.RestoreProductRequest) (*pb.Product, error) {
+	tx := storage.StorageInstance.BeginTransaction()
+
+	// The lock keeps the purge job from removing the product while it is restored
+	product, err := storage.StorageInstance.Product.GetDeletedWithLock(req.GetId(), tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, status.Errorf(codes.NotFound, "deleted product %d not found", req.GetId())
+	}
 	if err != nil {
-		return err
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
-	return nil
+	if product.MerchantId != req.GetMerchantId() {
+		tx.Rollback()
+		return nil, status.Error(codes.PermissionDenied, "product does not belong to the merchant")
+	}
+
+	if err := storage.StorageInstance.Product.Restore(product.Id, tx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to restore product: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return p.GetProduct(product.Id, req.GetMerchantId())
 }
 
 func (p *ProductService) ListProducts(req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	filter, err := p.productFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	productSort, err := parseProductSort(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+	page := storage.ProductPage{
+		Sort:   productSort,
+		Limit:  pageLimit(req.GetLimit()),
+		Token:  req.GetPageToken(),
+		Cursor: req.GetCursor(),
+	}
 
+	var products_db []*models.Product
+	var token string
+	var total uint64
 	if req.GetMerchantId() != 0 {
-		products_db, cursor, total, err := storage.StorageInstance.Product.ListByMerchantId(req.GetMerchantId(), req.GetLimit(), req.GetCursor())
-		if err != nil {
+		products_db, token, total, err = storage.StorageInstance.Product.ListByMerchantId(req.GetMerchantId(), filter, page)
+	} else {
+		products_db, token, total, err = storage.StorageInstance.Product.List(filter, page)
+	}
+	if errors.Is(err, storage.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Older clients page by the ID of the last product
+	var cursor uint64
+	if productSort == storage.ProductSortId && len(products_db) > 0 {
+		cursor = products_db[len(products_db)-1].Id
+	}
+	resp := &pb.ListProductsResponse{
+		Products:      storage.DBsToGrpcs(products_db),
+		Cursor:        cursor,
+		Total:         total,
+		NextPageToken: token,
+	}
+
+	if req.GetIncludeFacets() {
+		if req.GetMerchantId() != 0 {
+			filter.MerchantId = req.GetMerchantId()
+		}
+		if resp.Facets, err = p.facets("", filter); err != nil {
 			return nil, err
 		}
-		return &pb.ListProductsResponse{
-			Products: storage.DBsToGrpcs(products_db),
-			Cursor:   cursor,
-			Total:    total,
-		}, nil
 	}
-	products_db, cursor, total, err := storage.StorageInstance.Product.List(req.GetLimit(), req.GetCursor())
+	return resp, nil
+}
+
+// ListMerchantProducts lists the merchant's own products, whatever their state
+func (p *ProductService) ListMerchantProducts(req *pb.ListMerchantProductsRequest) (*pb.ListProductsResponse, error) {
+	if req.GetMerchantId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+	productSort, err := parseProductSort(req.GetSort())
 	if err != nil {
 		return nil, err
 	}
 
+	filter := storage.ProductFilter{Currency: configs.DEFAULT_CURRENCY, IncludeDeleted: req.GetIncludeDeleted()}
+	for _, value := range req.GetStatuses() {
+		productStatus := models.ProductStatus(strings.ToLower(strings.TrimSpace(value)))
+		if !slices.Contains(models.ProductStatuses, productStatus) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown product status %q", productStatus)
+		}
+		filter.Statuses = append(filter.Statuses, productStatus)
+	}
+
+	products_db, token, total, err := storage.StorageInstance.Product.ListByMerchantId(req.GetMerchantId(), filter, storage.ProductPage{
+		Sort:  productSort,
+		Limit: pageLimit(req.GetLimit()),
+		Token: req.GetPageToken(),
+	})
+	if errors.Is(err, storage.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
 	return &pb.ListProductsResponse{
-		Products: storage.DBsToGrpcs(products_db),
-		Cursor:   cursor,
-		Total:    total,
+		Products:      storage.DBsToGrpcs(products_db),
+		Total:         total,
+		NextPageToken: token,
 	}, nil
 }
 
+// pageLimit is the number of products or orders on a page, clients asking for none get the default
+func pageLimit(limit uint64) uint64 {
+	if limit == 0 {
+		return defaultPageLimit
+	}
+	return min(limit, maxPageLimit)
+}
+
+// parseProductSort validates the sort order of a listing, sorting by ID when none is given
+func parseProductSort(sort string) (storage.ProductSort, error) {
+	productSort := storage.ProductSort(strings.ToLower(sort))
+	if productSort == "" {
+		productSort = storage.ProductSortId
+	}
+	if !slices.Contains(storage.ProductSorts, productSort) {
+		return "", status.Errorf(codes.InvalidArgument, "unknown sort %q", sort)
+	}
+	return productSort, nil
+}
+
+// SearchProducts finds products by the words in their name and description, best matches first
+func (p *ProductService) SearchProducts(req *pb.SearchProductsRequest) (*pb.ListProductsResponse, error) {
+	text := strings.TrimSpace(req.GetQuery())
+	if text == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	filter, err := p.categoryFilter(req.GetCategoryId())
+	if err != nil {
+		return nil, err
+	}
+	filter.PublishedOnly = true
+	filter.MerchantId = req.GetMerchantId()
+	filter.Currency = strings.ToUpper(req.GetCurrency())
+	if filter.Currency == "" {
+		filter.Currency = configs.DEFAULT_CURRENCY
+	}
+	if len(filter.Currency) != 3 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid currency %q", req.GetCurrency())
+	}
+
+	products_db, token, total, err := storage.StorageInstance.Product.Search(text, filter, storage.ProductPage{
+		Limit:  pageLimit(req.GetLimit()),
+		Token:  req.GetPageToken(),
+		Cursor: req.GetCursor(),
+	})
+	if errors.Is(err, storage.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Older clients page by the number of results they already got
+	var cursor uint64
+	if req.GetPageToken() == "" {
+		cursor = req.GetCursor() + uint64(len(products_db))
+	}
+	resp := &pb.ListProductsResponse{
+		Products:      storage.DBsToGrpcs(products_db),
+		Cursor:        cursor,
+		Total:         total,
+		NextPageToken: token,
+	}
+
+	if req.GetIncludeFacets() {
+		if resp.Facets, err = p.facets(text, filter); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// facets counts the products matching the listing per category, merchant, price bucket and specification
+func (p *ProductService) facets(text string, filter storage.ProductFilter) ([]*pb.Facet, error) {
+	priceBuckets := make([]int64, 0, len(configs.PRICE_FACET_BUCKETS))
+	for _, bound := range configs.PRICE_FACET_BUCKETS {
+		priceBuckets = append(priceBuckets, models.ToMinorUnits(float32(bound), filter.Currency))
+	}
+
+	facets, err := storage.StorageInstance.Product.Facets(text, filter, priceBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count facets: %w", err)
+	}
+	return storage.FacetsToGrpc(facets), nil
+}
+
+// productFilter validates the filters of a product listing
+func (p *ProductService) productFilter(req *pb.ListProductsRequest) (storage.ProductFilter, error) {
+	// Browsing a category includes everything below it
+	filter, err := p.categoryFilter(req.GetCategoryId())
+	if err != nil {
+		return filter, err
+	}
+	// Buyers only see published products, merchants list their own with ListMerchantProducts
+	filter.PublishedOnly = true
+
+	filter.Currency = strings.ToUpper(req.GetCurrency())
+	if filter.Currency == "" {
+		filter.Currency = configs.DEFAULT_CURRENCY
+	}
+	if len(filter.Currency) != 3 {
+		return filter, status.Errorf(codes.InvalidArgument, "invalid currency %q", req.GetCurrency())
+	}
+
+	filter.MinPriceMinor = req.GetMinPriceMinor()
+	filter.MaxPriceMinor = req.GetMaxPriceMinor()
+	if filter.MinPriceMinor < 0 || filter.MaxPriceMinor < 0 {
+		return filter, status.Error(codes.InvalidArgument, "price range can not be negative")
+	}
+	if filter.MaxPriceMinor > 0 && filter.MinPriceMinor > filter.MaxPriceMinor {
+		return filter, status.Error(codes.InvalidArgument, "min_price_minor is above max_price_minor")
+	}
+
+	filter.InStock = req.GetInStock()
+	for _, attribute := range req.GetAttributes() {
+		key := strings.ToLower(strings.TrimSpace(attribute.GetKey()))
+		if key == "" {
+			return filter, status.Error(codes.InvalidArgument, "attribute filter key is required")
+		}
+		filter.Attributes = append(filter.Attributes, storage.AttributeFilter{
+			Key:    key,
+			Values: attribute.GetValues(),
+			Min:    attribute.GetMin(),
+			Max:    attribute.GetMax(),
+		})
+	}
+	if req.GetCreatedAfter() != "" {
+		filter.CreatedAfter, err = time.Parse(time.RFC3339, req.GetCreatedAfter())
+		if err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "created_after must be an RFC 3339 timestamp: %v", err)
+		}
+	}
+	return filter, nil
+}
+
+// categoryFilter narrows a listing to the category and everything below it
+func (p *ProductService) categoryFilter(categoryId uint64) (storage.ProductFilter, error) {
+	var filter storage.ProductFilter
+	if categoryId == 0 {
+		return filter, nil
+	}
+
+	categoryIds, err := storage.StorageInstance.Category.ListDescendantIds(categoryId, nil)
+	if err != nil {
+		return filter, err
+	}
+	if len(categoryIds) == 0 {
+		return filter, status.Errorf(codes.NotFound, "category %d not found", categoryId)
+	}
+	filter.CategoryIds = categoryIds
+	return filter, nil
+}
+
 func (p *ProductService) UpdateProductImages(product *pb.Product) (*pb.Product, error) {
 
 	product_db, err := storage.StorageInstance.Product.UpdateImageUrl(storage.GrpcToDB(product))
@@ -113,13 +688,34 @@ func (p *ProductService) UpdateProductImages(product *pb.Product) (*pb.Product,
 	return storage.DBToGrpc(product_db), nil
 }
 
-func (p *ProductService) ValidateProductInventory(id, requestedQuantity uint64) (bool, error) {
+// ValidateProductInventory checks the stock of the product, or of its variant when one is given
+func (p *ProductService) ValidateProductInventory(id, variantId, requestedQuantity uint64) (bool, error) {
 	product, err := storage.StorageInstance.Product.Get(id, nil)
 	if err != nil {
 		return false, err
 	}
 
-	if product.Inventory < requestedQuantity {
+	if !product.IsPublished(time.Now()) {
+		return false, status.Errorf(codes.FailedPrecondition, "product %s is not available", product.Name)
+	}
+
+	inventory := product.Inventory
+	if variantId != 0 {
+		variant, err := storage.StorageInstance.Variant.GetVariant(variantId, nil)
+		if err != nil {
+			return false, err
+		}
+		if variant.ProductId != product.Id {
+			return false, status.Errorf(codes.NotFound, "variant %d of product %d not found", variantId, id)
+		}
+		inventory = variant.Inventory
+	}
+
+	available, err := availableStock(product.Id, variantId, inventory, nil)
+	if err != nil {
+		return false, err
+	}
+	if available < requestedQuantity {
 		return false, errors.New("insufficient inventory")
 	}
 	return true, nil
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"product/storage"
+	"time"
+)
+
+// ProductPublisher periodically publishes the scheduled products whose publish time has passed.
+// Listings already show due products, this keeps their status in line for merchants.
+type ProductPublisher struct {
+	interval time.Duration
+}
+
+func NewProductPublisher(interval time.Duration) *ProductPublisher {
+	return &ProductPublisher{
+		interval: interval,
+	}
+}
+
+// Start runs the publisher forever, it is meant to be called in its own goroutine
+func (p *ProductPublisher) Start() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := p.Publish(); err != nil {
+			log.Println("product publisher: ", err)
+		}
+	}
+}
+
+// Publish marks every scheduled product that is due as published
+func (p *ProductPublisher) Publish() error {
+	published, err := storage.StorageInstance.Product.PublishScheduled(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to publish scheduled products: %w", err)
+	}
+	if published > 0 {
+		log.Printf("product publisher: published %d scheduled products", published)
+	}
+	return nil
+}
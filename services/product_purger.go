@@ -0,0 +1,88 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"product/storage"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// purgeBatchSize caps how many deleted products are purged per run
+const purgeBatchSize = 100
+
+// ProductPurger periodically removes products that were deleted longer than the retention period ago.
+// Products that were ever ordered are kept, orders, refunds and returns still refer to them.
+type ProductPurger struct {
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewProductPurger(interval, retention time.Duration) *ProductPurger {
+	return &ProductPurger{
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Start runs the purger forever, it is meant to be called in its own goroutine
+func (p *ProductPurger) Start() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := p.Purge(); err != nil {
+			log.Println("product purger: ", err)
+		}
+	}
+}
+
+// Purge permanently removes the deleted products that are past the retention period
+func (p *ProductPurger) Purge() error {
+	products, err := storage.StorageInstance.Product.ListPurgeable(time.Now().Add(-p.retention), purgeBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list purgeable products: %w", err)
+	}
+
+	for _, product := range products {
+		if err := p.purgeProduct(product.Id); err != nil {
+			log.Printf("product purger: failed to purge product %d: %v", product.Id, err)
+		}
+	}
+	return nil
+}
+
+func (p *ProductPurger) purgeProduct(productId uint64) error {
+	tx := storage.StorageInstance.BeginTransaction()
+
+	// Re-check under the lock, the product may have been restored in the meantime
+	product, err := storage.StorageInstance.Product.GetDeletedWithLock(productId, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if err := storage.StorageInstance.Product.Delete(product.Id, tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	// Archived last so a failure leaves the product in place to be retried on the next run
+	if product.StripeProductId != "" {
+		if err := NewStripeService().ArchiveProduct(product.StripeProductId); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to archive stripe product: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"product/configs"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listedProducts records the page the service asked storage for
+type listedProducts struct {
+	storage.ProductInterface
+	page storage.ProductPage
+}
+
+func (l *listedProducts) List(filter storage.ProductFilter, page storage.ProductPage) ([]*models.Product, string, uint64, error) {
+	l.page = page
+	return []*models.Product{{Id: 1, Name: "Mug"}}, "", 1, nil
+}
+
+func (l *listedProducts) ListByMerchantId(merchantId uint64, filter storage.ProductFilter, page storage.ProductPage) ([]*models.Product, string, uint64, error) {
+	return l.List(filter, page)
+}
+
+func TestListProductsPageLimit(t *testing.T) {
+	configs.DEFAULT_CURRENCY = "SGD"
+	products := &listedProducts{}
+	storage.StorageInstance = &storage.Storage{Product: products}
+
+	for _, tc := range []struct {
+		limit uint64
+		want  uint64
+	}{
+		{0, defaultPageLimit},
+		{5, 5},
+		{maxPageLimit + 1, maxPageLimit},
+	} {
+		if _, err := NewProductService().ListProducts(&pb.ListProductsRequest{Limit: tc.limit}); err != nil {
+			t.Fatalf("ListProducts(limit %d): %v", tc.limit, err)
+		}
+		if products.page.Limit != tc.want {
+			t.Errorf("ListProducts(limit %d) asked for %d products, want %d", tc.limit, products.page.Limit, tc.want)
+		}
+
+		if _, err := NewProductService().ListMerchantProducts(&pb.ListMerchantProductsRequest{MerchantId: 1, Limit: tc.limit}); err != nil {
+			t.Fatalf("ListMerchantProducts(limit %d): %v", tc.limit, err)
+		}
+		if products.page.Limit != tc.want {
+			t.Errorf("ListMerchantProducts(limit %d) asked for %d products, want %d", tc.limit, products.page.Limit, tc.want)
+		}
+	}
+}
+
+func TestGetProductHidesUnpublishedProducts(t *testing.T) {
+	store, _ := useTestStorage(t)
+	store.Product = &stockedProducts{products: map[uint64]*models.Product{
+		1: {Id: 1, MerchantId: 9, Status: models.ProductStatusPublished},
+		2: {Id: 2, MerchantId: 9, Status: models.ProductStatusDraft},
+	}}
+
+	if _, err := NewProductService().GetProduct(1, 0); err != nil {
+		t.Errorf("published product: %v", err)
+	}
+	for _, merchantId := range []uint64{0, 8} {
+		if _, err := NewProductService().GetProduct(2, merchantId); status.Code(err) != codes.NotFound {
+			t.Errorf("draft product for merchant %d: got error %v, want NotFound", merchantId, err)
+		}
+	}
+	if _, err := NewProductService().GetProduct(2, 9); err != nil {
+		t.Errorf("draft product for its merchant: %v", err)
+	}
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type RefundService struct{}
+
+func NewRefundService() *RefundService {
+	return &RefundService{}
+}
+
+// RefundOrder refunds a paid order, either fully or for the requested items only.
+// Refunded quantities can optionally be put back into inventory, on behalf of the user refunding.
+// The refund is committed as pending before Stripe is asked for the money. When submitting it failed,
+// calling RefundOrder again with the same request submits that refund instead of refunding anew,
+// other refunds of the order are refused until it went through.
+func (r *RefundService) RefundOrder(req *pb.RefundOrderRequest, userId uint64) (*pb.RefundOrderResponse, error) {
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(req.GetOrderId(), tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, status.Errorf(codes.NotFound, "order %d not found", req.GetOrderId())
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	refund, err := storage.StorageInstance.Refund.GetUnsubmittedRefund(order.Id, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		source := models.StockMovement{Type: models.StockMovementRefundRestock, Actor: models.UserStockActor(userId)}
+		refund, err = r.refundOrder(order, req, source, tx)
+	} else if err != nil {
+		err = fmt.Errorf("failed to get unsubmitted refund: %w", err)
+	} else if !r.isRetryOf(order, req, refund) {
+		err = status.Errorf(codes.FailedPrecondition, "refund %d of order %d was not submitted to Stripe yet, retry it with the same request first", refund.Id, order.Id)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := r.submitRefund(order, refund); err != nil {
+		return nil, err
+	}
+
+	orderGrpc, err := NewOrderService(nil).GetOrder(order.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RefundOrderResponse{
+		Order:  orderGrpc,
+		Refund: storage.RefundDBToGrpc(refund),
+	}, nil
+}
+
+// isRetryOf reports whether the request asks for the same refund as the unsubmitted one.
+// A request without items matches when the refund left nothing refundable, as it then refunded the whole order.
+func (r *RefundService) isRetryOf(order *models.Order, req *pb.RefundOrderRequest, refund *models.Refund) bool {
+	if req.GetRestock() != refund.Restocked || req.GetReason() != refund.Reason {
+		return false
+	}
+
+	if len(req.GetItems()) == 0 {
+		for _, item := range order.OrderItems {
+			if item.RefundableQuantity() > 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	requested := make(map[models.LineKey]uint64, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		requested[models.LineKey{ProductId: item.GetProductId(), VariantId: item.GetVariantId()}] += item.GetQuantity()
+	}
+	refunded := make(map[models.LineKey]uint64, len(refund.Items))
+	for _, item := range refund.Items {
+		refunded[item.Key()] += item.Quantity
+	}
+	return maps.Equal(requested, refunded)
+}
+
+// refundOrder saves a pending refund of the order locked in tx, the caller commits or rolls back
+// and then submits the refund to Stripe with submitRefund.
+// Restocked quantities are recorded in the stock ledger as coming from source,
+// which references the refund unless it already references something else.
+func (r *RefundService) refundOrder(order *models.Order, req *pb.RefundOrderRequest, source models.StockMovement, tx *gorm.DB) (*models.Refund, error) {
+	if order.IsSubOrder() {
+		return nil, status.Errorf(codes.InvalidArgument, "order %d is a merchant sub-order, refund order %d instead", order.Id, *order.ParentId)
+	}
+	if order.PaymentStatus != models.PaymentStatusCompleted && order.PaymentStatus != models.PaymentStatusPartiallyRefunded {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with payment status %s can not be refunded", order.PaymentStatus)
+	}
+	if order.TransactionId == "" {
+		return nil, status.Error(codes.FailedPrecondition, "order has no recorded payment to refund")
+	}
+
+	refund, err := r.buildRefund(order, req)
+	if err != nil {
+		return nil, err
+	}
+
+	refund, err = storage.StorageInstance.Refund.CreateRefund(refund, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save refund: %w", err)
+	}
+
+	for i := range order.OrderItems {
+		if err := storage.StorageInstance.Order.UpdateOrderItem(&order.OrderItems[i], tx); err != nil {
+			return nil, fmt.Errorf("failed to update order item: %w", err)
+		}
+	}
+
+	if refund.Restocked {
+		if source.ReferenceId == 0 {
+			source.ReferenceId = refund.Id
+		}
+		if err := r.restock(refund, source, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := updateRefundStatus(order, tx); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// updateRefundStatus sets the statuses of the order and its sub-orders from what is refunded of their items.
+// Only what is refunded in full moves to refunded, the rest keeps tracking its shipping progress
+// and shows a partial refund in its payment status only.
+func updateRefundStatus(order *models.Order, tx *gorm.DB) error {
+	var quantity, refunded uint64
+	for _, item := range order.OrderItems {
+		quantity += item.Quantity
+		refunded += item.RefundedQuantity
+	}
+	setRefundStatus(order, quantity, refunded)
+	if err := storage.StorageInstance.Order.UpdateOrder(order, tx); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	for i := range order.SubOrders {
+		subOrder := &order.SubOrders[i]
+		var quantity, refunded uint64
+		for _, item := range order.OrderItems {
+			if item.SubOrderId != nil && *item.SubOrderId == subOrder.Id {
+				quantity += item.Quantity
+				refunded += item.RefundedQuantity
+			}
+		}
+		setRefundStatus(subOrder, quantity, refunded)
+		if err := storage.StorageInstance.Order.UpdateOrder(subOrder, tx); err != nil {
+			return fmt.Errorf("failed to update sub-order: %w", err)
+		}
+	}
+
+	// Orders that are not refunded in full get back the shipping progress a refund status replaced
+	return updateFulfilmentStatus(order, tx)
+}
+
+// setRefundStatus moves the order to refunded once all of its quantity is refunded,
+// otherwise only its payment status shows whether part of it was refunded
+func setRefundStatus(order *models.Order, quantity, refunded uint64) {
+	if quantity > 0 && refunded >= quantity {
+		order.Status = models.OrderStatusRefunded
+		order.PaymentStatus = models.PaymentStatusRefunded
+		return
+	}
+
+	order.PaymentStatus = models.PaymentStatusCompleted
+	if refunded > 0 {
+		order.PaymentStatus = models.PaymentStatusPartiallyRefunded
+	}
+	if order.Status == models.OrderStatusRefunded || order.Status == models.OrderStatusPartiallyRefunded {
+		order.Status = models.OrderStatusCompleted
+	}
+}
+
+// submitRefund asks Stripe for the money of a committed pending refund, then records the outcome
+// and takes the refunded share back from the merchants in a second transaction.
+// The idempotency key is derived from the committed refund, so submitting it again never refunds twice.
+func (r *RefundService) submitRefund(order *models.Order, refund *models.Refund) error {
+	stripeRefund, err := NewStripeService().RefundPayment(order.TransactionId, refund.AmountMinor,
+		fmt.Sprintf("order-%d-refund-%d", order.Id, refund.Id),
+		map[string]string{
+			"orderId":  fmt.Sprint(order.Id),
+			"refundId": fmt.Sprint(refund.Id),
+		})
+	if err != nil {
+		return fmt.Errorf("failed to create stripe refund: %w", err)
+	}
+
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err = storage.StorageInstance.Order.GetOrderWithLock(order.Id, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	refund.StripeRefundId = stripeRefund.ID
+	refund.Status = models.RefundStatus(stripeRefund.Status)
+	if err := storage.StorageInstance.Refund.UpdateRefund(refund, tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+
+	if refund.Status == models.RefundStatusFailed || refund.Status == models.RefundStatusCancelled {
+		log.Printf("refund %d of order %d was %s by stripe", refund.Id, order.Id, refund.Status)
+		if err := r.undoRefund(order, refund, tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+	} else if err := NewPayoutService().ReverseRefund(order, refund, tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to reverse merchant payouts: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// undoRefund makes the items of a refund that Stripe did not pay out refundable again,
+// takes restocked items back out of the inventory and restores the statuses of the order locked in tx.
+func (r *RefundService) undoRefund(order *models.Order, refund *models.Refund, tx *gorm.DB) error {
+	quantities := make(map[models.LineKey]uint64, len(refund.Items))
+	for _, item := range refund.Items {
+		quantities[item.Key()] += item.Quantity
+	}
+
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		quantity, ok := quantities[item.Key()]
+		if !ok {
+			continue
+		}
+		item.RefundedQuantity -= min(quantity, item.RefundedQuantity)
+		if err := storage.StorageInstance.Order.UpdateOrderItem(item, tx); err != nil {
+			return fmt.Errorf("failed to update order item: %w", err)
+		}
+	}
+
+	if refund.Restocked {
+		source := models.StockMovement{Type: models.StockMovementRefundFailed, Actor: models.StockActorSystem, ReferenceId: refund.Id}
+		if err := takeLines(quantities, source, tx); err != nil {
+			return err
+		}
+	}
+
+	return updateRefundStatus(order, tx)
+}
+
+// buildRefund validates the requested items against what is still refundable,
+// and marks them as refunded on the order items.
+func (r *RefundService) buildRefund(order *models.Order, req *pb.RefundOrderRequest) (*models.Refund, error) {
+	requested := make(map[models.LineKey]uint64, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		requested[models.LineKey{ProductId: item.GetProductId(), VariantId: item.GetVariantId()}] += item.GetQuantity()
+	}
+
+	refund := &models.Refund{
+		OrderId:   order.Id,
+		Currency:  order.Currency,
+		Reason:    req.GetReason(),
+		Status:    models.RefundStatusPending,
+		Restocked: req.GetRestock(),
+	}
+
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+
+		quantity := item.RefundableQuantity()
+		if len(requested) > 0 {
+			var ok bool
+			if quantity, ok = requested[item.Key()]; !ok {
+				continue
+			}
+			delete(requested, item.Key())
+			if quantity > item.RefundableQuantity() {
+				return nil, status.Errorf(codes.InvalidArgument, "only %d of product %d can still be refunded", item.RefundableQuantity(), item.ProductId)
+			}
+		}
+		if quantity == 0 {
+			continue
+		}
+
+		amount := lineAmount(item.PriceMinor, quantity)
+		refund.Items = append(refund.Items, models.RefundItem{
+			ProductId:   item.ProductId,
+			VariantId:   item.VariantId,
+			Quantity:    quantity,
+			AmountMinor: amount,
+		})
+		refund.AmountMinor += amount
+		item.RefundedQuantity += quantity
+	}
+
+	if len(requested) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "refund contains products that are not part of order %d", order.Id)
+	}
+	if len(refund.Items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "there is nothing left to refund")
+	}
+	return refund, nil
+}
+
+// restock returns refunded quantities to inventory
+func (r *RefundService) restock(refund *models.Refund, source models.StockMovement, tx *gorm.DB) error {
+	quantities := make(map[models.LineKey]uint64, len(refund.Items))
+	for _, item := range refund.Items {
+		quantities[item.Key()] += item.Quantity
+	}
+	return restockLines(quantities, source, tx)
+}
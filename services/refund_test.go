@@ -0,0 +1,227 @@
+package services
+
+import (
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"testing"
+
+	"github.com/stripe/stripe-go/v81"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// savedRefunds keeps refunds in memory
+type savedRefunds struct {
+	storage.RefundInterface
+	refunds []*models.Refund
+}
+
+func (s *savedRefunds) CreateRefund(refund *models.Refund, tx *gorm.DB) (*models.Refund, error) {
+	refund.Id = uint64(len(s.refunds) + 1)
+	saved := *refund
+	s.refunds = append(s.refunds, &saved)
+	return refund, nil
+}
+
+func (s *savedRefunds) UpdateRefund(refund *models.Refund, tx *gorm.DB) error {
+	saved := s.refunds[refund.Id-1]
+	saved.StripeRefundId = refund.StripeRefundId
+	saved.Status = refund.Status
+	return nil
+}
+
+func (s *savedRefunds) GetUnsubmittedRefund(orderId uint64, tx *gorm.DB) (*models.Refund, error) {
+	for _, refund := range s.refunds {
+		if refund.OrderId == orderId && refund.Status == models.RefundStatusPending && refund.StripeRefundId == "" {
+			found := *refund
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// recordedLedger keeps ledger entries in memory
+type recordedLedger struct {
+	storage.LedgerInterface
+	entries []*models.LedgerEntry
+}
+
+func (r *recordedLedger) CreateEntries(entries []*models.LedgerEntry, tx *gorm.DB) error {
+	for _, entry := range entries {
+		if entry.Key != nil && r.find(*entry.Key) != nil {
+			continue
+		}
+		r.entries = append(r.entries, entry)
+	}
+	return nil
+}
+
+// find returns the entry recorded with key, if any
+func (r *recordedLedger) find(key string) *models.LedgerEntry {
+	for _, entry := range r.entries {
+		if entry.Key != nil && *entry.Key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (r *recordedLedger) ListByOrderId(orderId uint64, tx *gorm.DB) ([]*models.LedgerEntry, error) {
+	var entries []*models.LedgerEntry
+	for _, entry := range r.entries {
+		if entry.OrderId == orderId {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// paidOrder is order 1, paid for 2 mugs of merchant 9 who were sold through sub-order 2
+func paidOrder() *models.Order {
+	parentId, subOrderId := uint64(1), uint64(2)
+	return &models.Order{
+		Id:            1,
+		UserId:        5,
+		Currency:      "SGD",
+		TotalMinor:    1000,
+		Status:        models.OrderStatusCompleted,
+		PaymentStatus: models.PaymentStatusCompleted,
+		TransactionId: "pi_1",
+		OrderItems: []models.OrderItem{
+			{OrderId: 1, ProductId: 1, SubOrderId: &subOrderId, Quantity: 2, PriceMinor: 500, CommissionBps: 1000},
+		},
+		SubOrders: []models.Order{
+			{Id: 2, ParentId: &parentId, MerchantId: 9, Currency: "SGD", TotalMinor: 1000,
+				Status: models.OrderStatusCompleted, PaymentStatus: models.PaymentStatusCompleted},
+		},
+	}
+}
+
+func TestRefundOrderResubmitsUnsubmittedRefund(t *testing.T) {
+	store, pool := useTestStorage(t)
+	store.Order = &savedOrders{orders: map[uint64]*models.Order{1: paidOrder()}}
+	refunds := &savedRefunds{}
+	store.Refund = refunds
+	store.Ledger = &recordedLedger{}
+	fake := useTestStripe(t)
+	fake.fail = 1
+
+	req := &pb.RefundOrderRequest{OrderId: 1, Items: []*pb.RefundItem{{ProductId: 1, Quantity: 1}}}
+	if _, err := NewRefundService().RefundOrder(req, 1); err == nil {
+		t.Fatal("RefundOrder with stripe unavailable: got no error")
+	}
+	// The refund was committed before stripe was asked, and is still waiting to be submitted
+	if pool.committed != 1 {
+		t.Errorf("committed %d transactions before calling stripe, want 1", pool.committed)
+	}
+	if len(refunds.refunds) != 1 || refunds.refunds[0].Status != models.RefundStatusPending {
+		t.Fatalf("refunds %+v, want a single pending one", refunds.refunds)
+	}
+
+	// Another refund has to wait until the first one went through
+	other := &pb.RefundOrderRequest{OrderId: 1, Items: []*pb.RefundItem{{ProductId: 1, Quantity: 1}}, Restock: true}
+	if _, err := NewRefundService().RefundOrder(other, 1); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("refunding differently while a refund is unsubmitted: got %v, want FailedPrecondition", err)
+	}
+
+	res, err := NewRefundService().RefundOrder(req, 1)
+	if err != nil {
+		t.Fatalf("retrying RefundOrder: %v", err)
+	}
+	if len(refunds.refunds) != 1 {
+		t.Errorf("retrying created %d refunds, want the first one to be submitted", len(refunds.refunds))
+	}
+	if res.GetRefund().GetStatus() != string(models.RefundStatusSucceeded) || refunds.refunds[0].Status != models.RefundStatusSucceeded {
+		t.Errorf("refund is %s, want succeeded", refunds.refunds[0].Status)
+	}
+
+	calls := fake.callsTo("/v1/refunds")
+	if len(calls) != 2 {
+		t.Fatalf("stripe was asked for %d refunds, want 2", len(calls))
+	}
+	if calls[0].idempotencyKey == "" || calls[0].idempotencyKey != calls[1].idempotencyKey {
+		t.Errorf("idempotency keys %q and %q, want the same one", calls[0].idempotencyKey, calls[1].idempotencyKey)
+	}
+	if item := store.Order.(*savedOrders).orders[1].OrderItems[0]; item.RefundedQuantity != 1 {
+		t.Errorf("refunded %d of the item, want 1", item.RefundedQuantity)
+	}
+}
+
+func TestPartialRefundKeepsShippingProgress(t *testing.T) {
+	store, _ := useTestStorage(t)
+	order := paidOrder()
+	order.Status = models.OrderStatusShipped
+	order.OrderItems[0].ShippedQuantity = 2
+	order.SubOrders[0].Status = models.OrderStatusShipped
+	order.SubOrders[0].Shipments = []models.Shipment{{Status: models.ShipmentStatusShipped}}
+	store.Order = &savedOrders{orders: map[uint64]*models.Order{1: order}}
+	store.Refund = &savedRefunds{}
+	store.Ledger = &recordedLedger{}
+	useTestStripe(t)
+
+	req := &pb.RefundOrderRequest{OrderId: 1, Items: []*pb.RefundItem{{ProductId: 1, Quantity: 1}}}
+	if _, err := NewRefundService().RefundOrder(req, 1); err != nil {
+		t.Fatalf("RefundOrder: %v", err)
+	}
+	for _, o := range []*models.Order{order, &order.SubOrders[0]} {
+		if o.Status != models.OrderStatusShipped || o.PaymentStatus != models.PaymentStatusPartiallyRefunded {
+			t.Errorf("order %d is %s/%s, want shipped/partially_refunded", o.Id, o.Status, o.PaymentStatus)
+		}
+	}
+
+	if _, err := NewRefundService().RefundOrder(req, 1); err != nil {
+		t.Fatalf("refunding the rest: %v", err)
+	}
+	for _, o := range []*models.Order{order, &order.SubOrders[0]} {
+		if o.Status != models.OrderStatusRefunded || o.PaymentStatus != models.PaymentStatusRefunded {
+			t.Errorf("order %d is %s/%s, want refunded", o.Id, o.Status, o.PaymentStatus)
+		}
+	}
+}
+
+func TestFailedRefundIsUndone(t *testing.T) {
+	store, _ := useTestStorage(t)
+	order := paidOrder()
+	store.Order = &savedOrders{orders: map[uint64]*models.Order{1: order}}
+	refunds := &savedRefunds{}
+	store.Refund = refunds
+	store.Ledger = &recordedLedger{}
+	store.Product = &stockedProducts{products: map[uint64]*models.Product{1: {Id: 1, Inventory: 5}}}
+	stock := &recordedStock{}
+	store.StockLedger = stock
+	fake := useTestStripe(t)
+	fake.refundStatus = stripe.RefundStatusFailed
+
+	req := &pb.RefundOrderRequest{OrderId: 1, Restock: true}
+	if _, err := NewRefundService().RefundOrder(req, 1); err != nil {
+		t.Fatalf("RefundOrder: %v", err)
+	}
+
+	if refunds.refunds[0].Status != models.RefundStatusFailed {
+		t.Errorf("refund is %s, want failed", refunds.refunds[0].Status)
+	}
+	if item := order.OrderItems[0]; item.RefundedQuantity != 0 {
+		t.Errorf("%d of the item is still refunded, want 0", item.RefundedQuantity)
+	}
+	for _, o := range []*models.Order{order, &order.SubOrders[0]} {
+		if o.Status != models.OrderStatusCompleted || o.PaymentStatus != models.PaymentStatusCompleted {
+			t.Errorf("order %d is %s/%s, want completed", o.Id, o.Status, o.PaymentStatus)
+		}
+	}
+	// The restock is taken back out of the inventory
+	var delta int64
+	types := map[models.StockMovementType]bool{}
+	for _, movement := range stock.movements {
+		delta += movement.Delta
+		types[movement.Type] = true
+	}
+	if delta != 0 || !types[models.StockMovementRefundRestock] || !types[models.StockMovementRefundFailed] {
+		t.Errorf("stock movements %+v, want the restock taken back", stock.movements)
+	}
+	// Nothing was paid back, so the merchant keeps the share
+	if entries := store.Ledger.(*recordedLedger).entries; len(entries) != 0 {
+		t.Errorf("ledger entries %+v, want none", entries)
+	}
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"product/auth"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ReturnService struct{}
+
+func NewReturnService() *ReturnService {
+	return &ReturnService{}
+}
+
+// RequestReturn opens a return for items of a paid order.
+// The items are held by the return until the merchant approves or rejects it.
+func (r *ReturnService) RequestReturn(req *pb.RequestReturnRequest) (*pb.Return, error) {
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a return needs at least one item")
+	}
+
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(req.GetOrderId(), tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, status.Errorf(codes.NotFound, "order %d not found", req.GetOrderId())
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order.IsSubOrder() {
+		tx.Rollback()
+		return nil, status.Errorf(codes.InvalidArgument, "order %d is a merchant sub-order, return items of order %d instead", order.Id, *order.ParentId)
+	}
+	if order.UserId != req.GetUserId() {
+		tx.Rollback()
+		return nil, status.Error(codes.PermissionDenied, "order does not belong to the user")
+	}
+	if order.PaymentStatus != models.PaymentStatusCompleted && order.PaymentStatus != models.PaymentStatusPartiallyRefunded {
+		tx.Rollback()
+		return nil, status.Errorf(codes.FailedPrecondition, "order with payment status %s can not be returned", order.PaymentStatus)
+	}
+
+	ret, err := r.buildReturn(order, req)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	ret, err = storage.StorageInstance.Return.CreateReturn(ret, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to save return: %w", err)
+	}
+
+	for i := range order.OrderItems {
+		if err := storage.StorageInstance.Order.UpdateOrderItem(&order.OrderItems[i], tx); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to update order item: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return storage.ReturnDBToGrpc(ret), nil
+}
+
+// ApproveReturn accepts the returned items, optionally putting them back into
+// inventory and refunding the buyer for them.
+func (r *ReturnService) ApproveReturn(req *pb.ApproveReturnRequest) (*pb.Return, error) {
+	var refundedOrder *models.Order
+	var refund *models.Refund
+	ret, err := r.decideReturn(req.GetId(), req.GetMerchantId(), models.ReturnStatusApproved, req.GetNote(),
+		func(order *models.Order, ret *models.Return, tx *gorm.DB) error {
+			source := models.StockMovement{Type: models.StockMovementReturnRestock, Actor: models.UserStockActor(req.GetMerchantId()), ReferenceId: ret.Id}
+			if !req.GetRefund() {
+				if req.GetRestock() {
+					if err := r.restock(ret, source, tx); err != nil {
+						return err
+					}
+					ret.Restocked = true
+				}
+				return nil
+			}
+
+			// Refunded units are no longer held by the return, the refund accounts for them
+			items := make([]*pb.RefundItem, 0, len(ret.Items))
+			for _, item := range ret.Items {
+				items = append(items, &pb.RefundItem{
+					ProductId: item.ProductId,
+					VariantId: item.VariantId,
+					Quantity:  item.Quantity,
+				})
+			}
+			var err error
+			refund, err = NewRefundService().refundOrder(order, &pb.RefundOrderRequest{
+				OrderId: order.Id,
+				Items:   items,
+				Restock: req.GetRestock(),
+				Reason:  fmt.Sprintf("return %d: %s", ret.Id, ret.Reason),
+			}, source, tx)
+			if err != nil {
+				return err
+			}
+			refundedOrder = order
+			ret.RefundId = &refund.Id
+			ret.Restocked = refund.Restocked
+			return r.releaseItems(order, ret, tx)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// The refund is committed with the approval, Stripe is only asked for the money afterwards
+	if refund != nil {
+		if err := NewRefundService().submitRefund(refundedOrder, refund); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "return %d was approved but refund %d could not be submitted, retry with RefundOrder: %v", ret.GetId(), refund.Id, err)
+		}
+	}
+	return ret, nil
+}
+
+// RejectReturn turns the return down, the items can be returned again later
+func (r *ReturnService) RejectReturn(req *pb.RejectReturnRequest) (*pb.Return, error) {
+	return r.decideReturn(req.GetId(), req.GetMerchantId(), models.ReturnStatusRejected, req.GetNote(), r.releaseItems)
+}
+
+func (r *ReturnService) GetReturn(id uint64, identity *auth.Identity) (*pb.Return, error) {
+	ret, err := storage.StorageInstance.Return.GetReturn(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "return %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+	// Like orders, returns of others are not found
+	if !canSee(identity, ret.UserId, ret.MerchantId) {
+		return nil, status.Errorf(codes.NotFound, "return %d not found", id)
+	}
+	return storage.ReturnDBToGrpc(ret), nil
+}
+
+func (r *ReturnService) ListOrderReturns(orderId uint64, identity *auth.Identity) (*pb.ListOrderReturnsResponse, error) {
+	returns, err := storage.StorageInstance.Return.ListByOrderId(orderId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list returns: %w", err)
+	}
+	// Merchants only see the returns of their own items
+	visible := make([]*models.Return, 0, len(returns))
+	for _, ret := range returns {
+		if canSee(identity, ret.UserId, ret.MerchantId) {
+			visible = append(visible, ret)
+		}
+	}
+	return &pb.ListOrderReturnsResponse{
+		Returns: storage.ReturnDBsToGrpcs(visible),
+	}, nil
+}
+
+// decideReturn moves a requested return to its final status under the order lock,
+// applying the side effects of the decision and recording it in the history.
+func (r *ReturnService) decideReturn(id, merchantId uint64, decision models.ReturnStatus, note string, apply func(order *models.Order, ret *models.Return, tx *gorm.DB) error) (*pb.Return, error) {
+	ret, err := storage.StorageInstance.Return.GetReturn(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "return %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+	if ret.MerchantId != merchantId {
+		return nil, status.Error(codes.PermissionDenied, "return does not belong to the merchant")
+	}
+
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(ret.OrderId, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	// Re-read under the order lock, the return may have been decided in the meantime
+	ret, err = storage.StorageInstance.Return.GetReturn(id, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+	if ret.Status != models.ReturnStatusRequested {
+		tx.Rollback()
+		return nil, status.Errorf(codes.FailedPrecondition, "return is already %s", ret.Status)
+	}
+
+	if err := apply(order, ret, tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	ret.Status = decision
+	if err := storage.StorageInstance.Return.UpdateReturn(ret, tx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update return: %w", err)
+	}
+	change := &models.ReturnStatusChange{
+		ReturnId: ret.Id,
+		Status:   decision,
+		Note:     note,
+		ActorId:  merchantId,
+	}
+	if err := storage.StorageInstance.Return.CreateStatusChange(change, tx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record return status: %w", err)
+	}
+	ret.History = append(ret.History, *change)
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return storage.ReturnDBToGrpc(ret), nil
+}
+
+// buildReturn validates the requested items against what can still be returned,
+// and marks them as returned on the order items.
+func (r *ReturnService) buildReturn(order *models.Order, req *pb.RequestReturnRequest) (*models.Return, error) {
+	requested := make(map[models.LineKey]uint64, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		requested[models.LineKey{ProductId: item.GetProductId(), VariantId: item.GetVariantId()}] += item.GetQuantity()
+	}
+
+	ret := &models.Return{
+		OrderId: order.Id,
+		UserId:  order.UserId,
+		Reason:  req.GetReason(),
+		Status:  models.ReturnStatusRequested,
+		History: []models.ReturnStatusChange{{
+			Status:  models.ReturnStatusRequested,
+			Note:    req.GetReason(),
+			ActorId: order.UserId,
+		}},
+	}
+
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+
+		quantity, ok := requested[item.Key()]
+		if !ok {
+			continue
+		}
+		delete(requested, item.Key())
+		if quantity == 0 || quantity > item.ReturnableQuantity() {
+			return nil, status.Errorf(codes.InvalidArgument, "only %d of product %d can be returned", item.ReturnableQuantity(), item.ProductId)
+		}
+
+		if item.SubOrderId == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "product %d is not assigned to a merchant", item.ProductId)
+		}
+		if ret.SubOrderId != 0 && ret.SubOrderId != *item.SubOrderId {
+			return nil, status.Error(codes.InvalidArgument, "items sold by different merchants must be returned separately")
+		}
+		ret.SubOrderId = *item.SubOrderId
+
+		ret.Items = append(ret.Items, models.ReturnItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  quantity,
+		})
+		item.ReturnedQuantity += quantity
+	}
+
+	if len(requested) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "return contains products that are not part of order %d", order.Id)
+	}
+
+	for _, subOrder := range order.SubOrders {
+		if subOrder.Id == ret.SubOrderId {
+			ret.MerchantId = subOrder.MerchantId
+		}
+	}
+	return ret, nil
+}
+
+// releaseItems stops the return from holding its items on the order
+func (r *ReturnService) releaseItems(order *models.Order, ret *models.Return, tx *gorm.DB) error {
+	returned := make(map[models.LineKey]uint64, len(ret.Items))
+	for _, item := range ret.Items {
+		returned[item.Key()] = item.Quantity
+	}
+
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		quantity, ok := returned[item.Key()]
+		if !ok {
+			continue
+		}
+		item.ReturnedQuantity -= min(quantity, item.ReturnedQuantity)
+		if err := storage.StorageInstance.Order.UpdateOrderItem(item, tx); err != nil {
+			return fmt.Errorf("failed to update order item: %w", err)
+		}
+	}
+	return nil
+}
+
+// restock returns the items to inventory
+func (r *ReturnService) restock(ret *models.Return, source models.StockMovement, tx *gorm.DB) error {
+	quantities := make(map[models.LineKey]uint64, len(ret.Items))
+	for _, item := range ret.Items {
+		quantities[item.Key()] += item.Quantity
+	}
+	return restockLines(quantities, source, tx)
+}
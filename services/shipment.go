@@ -0,0 +1,282 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ShipmentService struct{}
+
+func NewShipmentService() *ShipmentService {
+	return &ShipmentService{}
+}
+
+// CreateShipment records a parcel the merchant handed to a carrier.
+// The sub-order, and once every merchant shipped the buyer's order, moves to shipped.
+func (s *ShipmentService) CreateShipment(req *pb.CreateShipmentRequest) (*pb.Shipment, error) {
+	if req.GetCarrier() == "" || req.GetTrackingNumber() == "" {
+		return nil, status.Error(codes.InvalidArgument, "carrier and tracking number are required")
+	}
+
+	subOrder, err := s.getSubOrder(req.GetOrderId(), req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock the buyer's order, it owns the order items and the statuses of all sub-orders
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(*subOrder.ParentId, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.PaymentStatus != models.PaymentStatusCompleted && order.PaymentStatus != models.PaymentStatusPartiallyRefunded {
+		tx.Rollback()
+		return nil, status.Errorf(codes.FailedPrecondition, "order with payment status %s can not be shipped", order.PaymentStatus)
+	}
+
+	now := time.Now()
+	shipment := &models.Shipment{
+		OrderId:        subOrder.Id,
+		MerchantId:     subOrder.MerchantId,
+		Carrier:        req.GetCarrier(),
+		TrackingNumber: req.GetTrackingNumber(),
+		Status:         models.ShipmentStatusShipped,
+		ShippedAt:      &now,
+	}
+	if err := s.buildShipmentItems(order, shipment, req.GetItems()); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	shipment, err = storage.StorageInstance.Shipment.CreateShipment(shipment, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to save shipment: %w", err)
+	}
+
+	for i := range order.OrderItems {
+		if err := storage.StorageInstance.Order.UpdateOrderItem(&order.OrderItems[i], tx); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to update order item: %w", err)
+		}
+	}
+
+	for i := range order.SubOrders {
+		if order.SubOrders[i].Id == shipment.OrderId {
+			order.SubOrders[i].Shipments = append(order.SubOrders[i].Shipments, *shipment)
+		}
+	}
+	if err := updateFulfilmentStatus(order, tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return storage.ShipmentDBToGrpc(shipment), nil
+}
+
+// UpdateShipment corrects the tracking details of a shipment or marks it as delivered.
+// The order becomes delivered once all of its shipments are.
+func (s *ShipmentService) UpdateShipment(req *pb.UpdateShipmentRequest) (*pb.Shipment, error) {
+	shipment, err := storage.StorageInstance.Shipment.GetShipment(req.GetId(), nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "shipment %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	subOrder, err := s.getSubOrder(shipment.OrderId, req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := storage.StorageInstance.BeginTransaction()
+
+	order, err := storage.StorageInstance.Order.GetOrderWithLock(*subOrder.ParentId, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	// Re-read under the order lock so concurrent updates are serialised
+	shipment, err = storage.StorageInstance.Shipment.GetShipment(req.GetId(), tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if req.GetCarrier() != "" {
+		shipment.Carrier = req.GetCarrier()
+	}
+	if req.GetTrackingNumber() != "" {
+		shipment.TrackingNumber = req.GetTrackingNumber()
+	}
+	switch models.ShipmentStatus(req.GetStatus()) {
+	case "", shipment.Status:
+	case models.ShipmentStatusDelivered:
+		now := time.Now()
+		shipment.Status = models.ShipmentStatusDelivered
+		shipment.DeliveredAt = &now
+	default:
+		tx.Rollback()
+		return nil, status.Errorf(codes.InvalidArgument, "shipment can not move from %s to %s", shipment.Status, req.GetStatus())
+	}
+
+	if err := storage.StorageInstance.Shipment.UpdateShipment(shipment, tx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update shipment: %w", err)
+	}
+
+	for i := range order.SubOrders {
+		for j := range order.SubOrders[i].Shipments {
+			if order.SubOrders[i].Shipments[j].Id == shipment.Id {
+				order.SubOrders[i].Shipments[j].Status = shipment.Status
+			}
+		}
+	}
+	if err := updateFulfilmentStatus(order, tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return storage.ShipmentDBToGrpc(shipment), nil
+}
+
+// getSubOrder returns the merchant's sub-order, shipments are always made per merchant
+func (s *ShipmentService) getSubOrder(id, merchantId uint64) (*models.Order, error) {
+	subOrder, err := storage.StorageInstance.Order.GetOrder(id, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "order %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if !subOrder.IsSubOrder() {
+		return nil, status.Errorf(codes.InvalidArgument, "order %d is not a merchant sub-order", id)
+	}
+	if subOrder.MerchantId != merchantId {
+		return nil, status.Error(codes.PermissionDenied, "order does not belong to the merchant")
+	}
+	return subOrder, nil
+}
+
+// buildShipmentItems validates the requested items against what the sub-order still has to ship,
+// and marks them as shipped on the order items.
+func (s *ShipmentService) buildShipmentItems(order *models.Order, shipment *models.Shipment, reqItems []*pb.ShipmentItem) error {
+	requested := make(map[models.LineKey]uint64, len(reqItems))
+	for _, item := range reqItems {
+		requested[models.LineKey{ProductId: item.GetProductId(), VariantId: item.GetVariantId()}] += item.GetQuantity()
+	}
+
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		if item.SubOrderId == nil || *item.SubOrderId != shipment.OrderId {
+			continue
+		}
+
+		quantity := item.ShippableQuantity()
+		if len(requested) > 0 {
+			var ok bool
+			if quantity, ok = requested[item.Key()]; !ok {
+				continue
+			}
+			delete(requested, item.Key())
+			if quantity > item.ShippableQuantity() {
+				return status.Errorf(codes.InvalidArgument, "only %d of product %d still have to be shipped", item.ShippableQuantity(), item.ProductId)
+			}
+		}
+		if quantity == 0 {
+			continue
+		}
+
+		shipment.Items = append(shipment.Items, models.ShipmentItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  quantity,
+		})
+		item.ShippedQuantity += quantity
+	}
+
+	if len(requested) > 0 {
+		return status.Errorf(codes.InvalidArgument, "shipment contains products that are not part of order %d", shipment.OrderId)
+	}
+	if len(shipment.Items) == 0 {
+		return status.Error(codes.FailedPrecondition, "there is nothing left to ship")
+	}
+	return nil
+}
+
+// updateFulfilmentStatus moves sub-orders whose lines were all shipped to shipped or delivered,
+// and the buyer's order once every sub-order got there. Refunded orders keep their refund status.
+func updateFulfilmentStatus(order *models.Order, tx *gorm.DB) error {
+	orderFulfilled := true
+	orderDelivered := true
+	for i := range order.SubOrders {
+		subOrder := &order.SubOrders[i]
+
+		shipped := len(subOrder.Shipments) > 0
+		for _, item := range order.OrderItems {
+			if item.SubOrderId != nil && *item.SubOrderId == subOrder.Id && item.ShippableQuantity() > 0 {
+				shipped = false
+			}
+		}
+
+		if subOrder.Status == models.OrderStatusRefunded {
+			continue
+		}
+		if !shipped {
+			orderFulfilled = false
+			orderDelivered = false
+			continue
+		}
+		if fulfilledStatus(subOrder) != models.OrderStatusDelivered {
+			orderDelivered = false
+		}
+
+		if subOrder.Status != models.OrderStatusCompleted && !subOrder.IsFulfilled() {
+			continue
+		}
+		subOrder.Status = fulfilledStatus(subOrder)
+		if err := storage.StorageInstance.Order.UpdateOrder(subOrder, tx); err != nil {
+			return fmt.Errorf("failed to update sub-order: %w", err)
+		}
+	}
+
+	if !orderFulfilled || (order.Status != models.OrderStatusCompleted && !order.IsFulfilled()) {
+		return nil
+	}
+	order.Status = models.OrderStatusShipped
+	if orderDelivered {
+		order.Status = models.OrderStatusDelivered
+	}
+	if err := storage.StorageInstance.Order.UpdateOrder(order, tx); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	return nil
+}
+
+// fulfilledStatus is delivered once every shipment of the sub-order arrived, shipped before that
+func fulfilledStatus(subOrder *models.Order) models.OrderStatus {
+	for _, shipment := range subOrder.Shipments {
+		if shipment.Status != models.ShipmentStatusDelivered {
+			return models.OrderStatusShipped
+		}
+	}
+	return models.OrderStatusDelivered
+}
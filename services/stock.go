@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type StockService struct{}
+
+func NewStockService() *StockService {
+	return &StockService{}
+}
+
+// GetStockHistory lists the stock movements of a product of the merchant, oldest first,
+// so the merchant can reconcile its inventory with what happened to it.
+func (s *StockService) GetStockHistory(req *pb.GetStockHistoryRequest) (*pb.GetStockHistoryResponse, error) {
+	if _, err := getMerchantProduct(req.GetProductId(), req.GetMerchantId()); err != nil {
+		return nil, err
+	}
+
+	filter := storage.StockMovementFilter{
+		VariantId: req.GetVariantId(),
+		Type:      models.StockMovementType(req.GetType()),
+	}
+	if filter.Type != "" && !slices.Contains(models.StockMovementTypes, filter.Type) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown stock movement type %q", filter.Type)
+	}
+
+	movements, cursor, err := storage.StorageInstance.StockLedger.ListByProductId(req.GetProductId(), filter, req.GetLimit(), req.GetCursor())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	return &pb.GetStockHistoryResponse{
+		Movements: storage.StockMovementsDBToGrpc(movements),
+		Cursor:    cursor,
+	}, nil
+}
+
+// stockMovement creates the ledger entry of a line from the source of the movement,
+// which only has its type, actor and reference set.
+func stockMovement(source models.StockMovement, line models.LineKey, delta int64, quantity uint64) *models.StockMovement {
+	movement := source
+	movement.ProductId = line.ProductId
+	movement.VariantId = line.VariantId
+	movement.Delta = delta
+	movement.Quantity = quantity
+	return &movement
+}
+
+// recordStock appends the movements to the stock ledger, in the transaction that changed the inventory
+func recordStock(movements []*models.StockMovement, tx *gorm.DB) error {
+	if err := storage.StorageInstance.StockLedger.CreateMovements(movements, tx); err != nil {
+		return fmt.Errorf("failed to record stock movements: %w", err)
+	}
+	return nil
+}
+
+// adjustStock sets the inventory of a product or variant to the one given by the merchant
+// and records the difference as a manual adjustment.
+// Like restockLines the product row is locked before the variant.
+func adjustStock(line models.LineKey, inventory uint64, actor string, tx *gorm.DB) error {
+	var previous uint64
+	var err error
+	if line.VariantId != 0 {
+		previous, err = storage.StorageInstance.Variant.SetVariantStockWithLock(line.VariantId, inventory, tx)
+	} else {
+		previous, err = storage.StorageInstance.Product.SetStockWithLock(line.ProductId, inventory, tx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set stock of product %d: %w", line.ProductId, err)
+	}
+	if previous == inventory {
+		return nil
+	}
+
+	source := models.StockMovement{Type: models.StockMovementManualAdjustment, Actor: actor}
+	return recordStock([]*models.StockMovement{stockMovement(source, line, int64(inventory)-int64(previous), inventory)}, tx)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"product/storage"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// errNoDatabase is returned for any SQL reaching the database, tests replace the tables they use with fakes
+var errNoDatabase = errors.New("no database in tests")
+
+// txPool lets services begin, commit and roll back transactions without a database.
+// It counts the transactions so tests can tell whether work was committed.
+type txPool struct {
+	begun, committed, rolledBack int
+}
+
+func (p *txPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (p *txPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (p *txPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (p *txPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *txPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.begun++
+	return p, nil
+}
+
+func (p *txPool) Commit() error {
+	p.committed++
+	return nil
+}
+
+func (p *txPool) Rollback() error {
+	p.rolledBack++
+	return nil
+}
+
+// useTestStorage replaces the storage with one whose transactions go to the returned pool.
+// Tests set the tables they need on the returned storage.
+func useTestStorage(t *testing.T) (*storage.Storage, *txPool) {
+	t.Helper()
+	pool := &txPool{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	storage.StorageInstance = &storage.Storage{}
+	storage.StorageInstance.UseDB(db, db)
+	return storage.StorageInstance, pool
+}
@@ -1,9 +1,19 @@
 package services
 
 import (
+	"fmt"
+	"product/configs"
+	"strings"
+
 	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/account"
+	"github.com/stripe/stripe-go/v81/accountlink"
+	"github.com/stripe/stripe-go/v81/paymentintent"
 	stripePrice "github.com/stripe/stripe-go/v81/price"
 	"github.com/stripe/stripe-go/v81/product"
+	"github.com/stripe/stripe-go/v81/refund"
+	"github.com/stripe/stripe-go/v81/transfer"
+	"github.com/stripe/stripe-go/v81/transferreversal"
 )
 
 type StripeService struct{}
@@ -12,12 +22,13 @@ func NewStripeService() *StripeService {
 	return &StripeService{}
 }
 
-func (s *StripeService) CreateNewProduct(name string, price float32) (*stripe.Product, error) {
+// CreateNewProduct creates the product with its default price, priceMinor is in minor units of the currency
+func (s *StripeService) CreateNewProduct(name string, priceMinor int64, currency string) (*stripe.Product, error) {
 	params := &stripe.ProductParams{
 		Name: stripe.String(name),
 		DefaultPriceData: &stripe.ProductDefaultPriceDataParams{
-			Currency:   stripe.String(string(stripe.CurrencySGD)),
-			UnitAmount: stripe.Int64(int64(price * 100)),
+			Currency:   stripe.String(strings.ToLower(currency)),
+			UnitAmount: stripe.Int64(priceMinor),
 		},
 	}
 
@@ -29,14 +40,8 @@ func (s *StripeService) CreateNewProduct(name string, price float32) (*stripe.Pr
 	return result, nil
 }
 
-func (s *StripeService) UpdateProductPrice(stripeProductId, stripePriceId string, price float32) (*stripe.Product, error) {
-	priceParams := &stripe.PriceParams{
-		Product:    stripe.String(stripeProductId),
-		Currency:   stripe.String(string(stripe.CurrencySGD)),
-		UnitAmount: stripe.Int64(int64(price * 100)),
-	}
-
-	priceData, err := stripePrice.New(priceParams)
+func (s *StripeService) UpdateProductPrice(stripeProductId, stripePriceId string, priceMinor int64, currency string) (*stripe.Product, error) {
+	priceData, err := s.CreateProductPrice(stripeProductId, priceMinor, currency)
 	if err != nil {
 		return nil, err
 	}
@@ -51,3 +56,136 @@ func (s *StripeService) UpdateProductPrice(stripeProductId, stripePriceId string
 
 	return result, nil
 }
+
+// ArchiveProduct deactivates the product so it can no longer be sold, Stripe keeps it for past payments
+func (s *StripeService) ArchiveProduct(stripeProductId string) error {
+	params := &stripe.ProductParams{
+		Active: stripe.Bool(false),
+	}
+	if _, err := product.Update(stripeProductId, params); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateProductPrice adds a price in another currency to the product, priceMinor is in minor units of the currency
+func (s *StripeService) CreateProductPrice(stripeProductId string, priceMinor int64, currency string) (*stripe.Price, error) {
+	params := &stripe.PriceParams{
+		Product:    stripe.String(stripeProductId),
+		Currency:   stripe.String(strings.ToLower(currency)),
+		UnitAmount: stripe.Int64(priceMinor),
+	}
+
+	result, err := stripePrice.New(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RefundPayment refunds part of a payment, amount is in minor units of the payment's currency.
+// The idempotency key guards against refunding twice when a request is retried.
+func (s *StripeService) RefundPayment(paymentIntentId string, amount int64, idempotencyKey string, metadata map[string]string) (*stripe.Refund, error) {
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(paymentIntentId),
+		Amount:        stripe.Int64(amount),
+		Reason:        stripe.String(string(stripe.RefundReasonRequestedByCustomer)),
+	}
+	params.SetIdempotencyKey(idempotencyKey)
+	for key, value := range metadata {
+		params.AddMetadata(key, value)
+	}
+
+	result, err := refund.New(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateConnectedAccount creates an Express account that the merchant's payouts are transferred to
+func (s *StripeService) CreateConnectedAccount(email string) (*stripe.Account, error) {
+	params := &stripe.AccountParams{
+		Type:  stripe.String(string(stripe.AccountTypeExpress)),
+		Email: stripe.String(email),
+		Capabilities: &stripe.AccountCapabilitiesParams{
+			Transfers: &stripe.AccountCapabilitiesTransfersParams{
+				Requested: stripe.Bool(true),
+			},
+		},
+	}
+
+	result, err := account.New(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateOnboardingLink returns a Stripe hosted page where the merchant completes their account details
+func (s *StripeService) CreateOnboardingLink(accountId string) (*stripe.AccountLink, error) {
+	params := &stripe.AccountLinkParams{
+		Account:    stripe.String(accountId),
+		RefreshURL: stripe.String(fmt.Sprintf("%s/merchant/stripe/refresh", configs.FRONTEND_URL)),
+		ReturnURL:  stripe.String(fmt.Sprintf("%s/merchant/stripe/return", configs.FRONTEND_URL)),
+		Type:       stripe.String("account_onboarding"),
+	}
+
+	result, err := accountlink.New(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetLatestChargeId returns the charge that paid the payment intent
+func (s *StripeService) GetLatestChargeId(paymentIntentId string) (string, error) {
+	result, err := paymentintent.Get(paymentIntentId, nil)
+	if err != nil {
+		return "", err
+	}
+	if result.LatestCharge == nil {
+		return "", fmt.Errorf("payment intent %s has no charge", paymentIntentId)
+	}
+
+	return result.LatestCharge.ID, nil
+}
+
+// TransferToMerchant sends the merchant's share of a charge to their connected account, amount is in minor units
+func (s *StripeService) TransferToMerchant(accountId string, amount int64, currency, transferGroup, chargeId, idempotencyKey string) (*stripe.Transfer, error) {
+	params := &stripe.TransferParams{
+		Amount:            stripe.Int64(amount),
+		Currency:          stripe.String(strings.ToLower(currency)),
+		Destination:       stripe.String(accountId),
+		TransferGroup:     stripe.String(transferGroup),
+		SourceTransaction: stripe.String(chargeId),
+	}
+	params.SetIdempotencyKey(idempotencyKey)
+
+	result, err := transfer.New(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReverseTransfer takes back part of a transfer from the merchant, amount is in minor units
+func (s *StripeService) ReverseTransfer(transferId string, amount int64, idempotencyKey string) (*stripe.TransferReversal, error) {
+	params := &stripe.TransferReversalParams{
+		ID:     stripe.String(transferId),
+		Amount: stripe.Int64(amount),
+	}
+	params.SetIdempotencyKey(idempotencyKey)
+
+	result, err := transferreversal.New(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
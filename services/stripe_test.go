@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stripe/stripe-go/v81"
+)
+
+// stripeCall is a request that reached the fake Stripe API
+type stripeCall struct {
+	method, path, idempotencyKey string
+}
+
+// fakeStripe answers Stripe API calls from memory, failing the first fail calls.
+// Every object it creates is named after the idempotency key of its request.
+type fakeStripe struct {
+	stripe.Backend
+	fail  int
+	calls []stripeCall
+	// refundStatus is the status of the refunds it creates, succeeded when empty
+	refundStatus stripe.RefundStatus
+	// sessionStatus is the status of the checkout sessions it returns, open when empty
+	sessionStatus stripe.CheckoutSessionStatus
+}
+
+func (f *fakeStripe) Call(method, path, key string, params stripe.ParamsContainer, v stripe.LastResponseSetter) error {
+	call := stripeCall{method: method, path: path}
+	if method == http.MethodPost && params.GetParams().IdempotencyKey != nil {
+		call.idempotencyKey = *params.GetParams().IdempotencyKey
+	}
+	f.calls = append(f.calls, call)
+	if f.fail > 0 {
+		f.fail--
+		return errors.New("stripe is unavailable")
+	}
+
+	switch object := v.(type) {
+	case *stripe.Refund:
+		object.ID = "re_" + call.idempotencyKey
+		object.Status = stripe.RefundStatusSucceeded
+		if f.refundStatus != "" {
+			object.Status = f.refundStatus
+		}
+	case *stripe.Transfer:
+		object.ID = "tr_" + call.idempotencyKey
+		object.Amount = *params.(*stripe.TransferParams).Amount
+	case *stripe.TransferReversal:
+		object.ID = "trr_" + call.idempotencyKey
+	case *stripe.CheckoutSession:
+		object.Status = stripe.CheckoutSessionStatusOpen
+		if f.sessionStatus != "" {
+			object.Status = f.sessionStatus
+		}
+	case *stripe.PaymentIntent:
+		object.LatestCharge = &stripe.Charge{ID: "ch_1"}
+	}
+	return nil
+}
+
+// callsTo returns the calls made to path
+func (f *fakeStripe) callsTo(path string) []stripeCall {
+	var calls []stripeCall
+	for _, call := range f.calls {
+		if call.path == path {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// useTestStripe sends the Stripe API calls of the test to the returned fake
+func useTestStripe(t *testing.T) *fakeStripe {
+	t.Helper()
+	fake := &fakeStripe{}
+	previous := stripe.GetBackend(stripe.APIBackend)
+	stripe.SetBackend(stripe.APIBackend, fake)
+	t.Cleanup(func() { stripe.SetBackend(stripe.APIBackend, previous) })
+	return fake
+}
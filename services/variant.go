@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"product/models"
+	pb "product/proto"
+	"product/storage"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type VariantService struct{}
+
+func NewVariantService() *VariantService {
+	return &VariantService{}
+}
+
+// SetProductOptions replaces the option axes of a product.
+// Existing variants must still match the new options.
+func (v *VariantService) SetProductOptions(req *pb.SetProductOptionsRequest) (*pb.Product, error) {
+	product, err := getMerchantProduct(req.GetProductId(), req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]models.ProductOption, 0, len(req.GetOptions()))
+	names := make(map[string]bool, len(req.GetOptions()))
+	for i, option := range req.GetOptions() {
+		name := strings.TrimSpace(option.GetName())
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "option name is required")
+		}
+		if names[strings.ToLower(name)] {
+			return nil, status.Errorf(codes.InvalidArgument, "option %s is listed more than once", name)
+		}
+		names[strings.ToLower(name)] = true
+		if len(option.GetValues()) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "option %s needs at least one value", name)
+		}
+		options = append(options, models.ProductOption{
+			ProductId: product.Id,
+			Name:      name,
+			Position:  uint32(i),
+			Values:    option.GetValues(),
+		})
+	}
+
+	for _, variant := range product.Variants {
+		if err := validateOptionValues(options, variant.OptionValues); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "variant %s does not match the new options: %s", variant.Sku, status.Convert(err).Message())
+		}
+	}
+
+	if err := storage.StorageInstance.Variant.SaveOptions(product.Id, options, nil); err != nil {
+		return nil, fmt.Errorf("failed to save options: %w", err)
+	}
+	product.Options = options
+	return storage.DBToGrpc(product), nil
+}
+
+func (v *VariantService) CreateProductVariant(req *pb.CreateProductVariantRequest) (*pb.ProductVariant, error) {
+	product, err := getMerchantProduct(req.GetProductId(), req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &models.ProductVariant{
+		ProductId:    product.Id,
+		Sku:          strings.TrimSpace(req.GetSku()),
+		OptionValues: req.GetOptionValues(),
+		PriceMinor:   req.GetPriceMinor(),
+		Inventory:    req.GetInventory(),
+	}
+	if err := v.validateVariant(product, variant); err != nil {
+		return nil, err
+	}
+	if err := v.setStripePrice(product, variant, nil); err != nil {
+		return nil, err
+	}
+
+	tx := storage.StorageInstance.BeginTransaction()
+	variant, err = storage.StorageInstance.Variant.CreateVariant(variant, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to save variant: %w", err)
+	}
+	if variant.Inventory > 0 {
+		source := models.StockMovement{Type: models.StockMovementImport, Actor: models.UserStockActor(req.GetMerchantId())}
+		line := models.LineKey{ProductId: variant.ProductId, VariantId: variant.Id}
+		if err := recordStock([]*models.StockMovement{stockMovement(source, line, int64(variant.Inventory), variant.Inventory)}, tx); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return storage.VariantDBToGrpc(variant), nil
+}
+
+// UpdateProductVariant replaces all fields of the variant with the ones in the request
+func (v *VariantService) UpdateProductVariant(req *pb.UpdateProductVariantRequest) (*pb.ProductVariant, error) {
+	existing, product, err := v.getMerchantVariant(req.GetId(), req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &models.ProductVariant{
+		Id:           existing.Id,
+		ProductId:    existing.ProductId,
+		Sku:          strings.TrimSpace(req.GetSku()),
+		OptionValues: req.GetOptionValues(),
+		PriceMinor:   req.GetPriceMinor(),
+		Inventory:    req.GetInventory(),
+		Images:       existing.Images,
+	}
+	if err := v.validateVariant(product, variant); err != nil {
+		return nil, err
+	}
+	if err := v.setStripePrice(product, variant, existing); err != nil {
+		return nil, err
+	}
+
+	// The inventory is set through the stock ledger first, which also locks the variant
+	tx := storage.StorageInstance.BeginTransaction()
+	if err := adjustStock(models.LineKey{ProductId: variant.ProductId, VariantId: variant.Id}, variant.Inventory, models.UserStockActor(req.GetMerchantId()), tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := storage.StorageInstance.Variant.UpdateVariant(variant, tx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update variant: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return storage.VariantDBToGrpc(variant), nil
+}
+
+func (v *VariantService) DeleteProductVariant(req *pb.DeleteProductVariantRequest) error {
+	variant, _, err := v.getMerchantVariant(req.GetId(), req.GetMerchantId())
+	if err != nil {
+		return err
+	}
+	if err := storage.StorageInstance.Variant.DeleteVariant(variant.Id, nil); err != nil {
+		return fmt.Errorf("failed to delete variant: %w", err)
+	}
+	return nil
+}
+
+// UpdateVariantImages sets the images of a variant of the product
+func (v *VariantService) UpdateVariantImages(productId, variantId uint64, images []string) error {
+	variant, err := storage.StorageInstance.Variant.GetVariant(variantId, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && variant.ProductId != productId) {
+		return status.Errorf(codes.NotFound, "variant %d of product %d not found", variantId, productId)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get variant: %w", err)
+	}
+	return storage.StorageInstance.Variant.UpdateVariantImages(variant.Id, images)
+}
+
+func (v *VariantService) getMerchantVariant(variantId, merchantId uint64) (*models.ProductVariant, *models.Product, error) {
+	variant, err := storage.StorageInstance.Variant.GetVariant(variantId, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "variant %d not found", variantId)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get variant: %w", err)
+	}
+	product, err := getMerchantProduct(variant.ProductId, merchantId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return variant, product, nil
+}
+
+// validateVariant checks the SKU is unique and the option values pick a combination no other variant has
+func (v *VariantService) validateVariant(product *models.Product, variant *models.ProductVariant) error {
+	if variant.Sku == "" {
+		return status.Error(codes.InvalidArgument, "sku is required")
+	}
+	if variant.PriceMinor < 0 {
+		return status.Error(codes.InvalidArgument, "price can not be negative")
+	}
+	if err := validateOptionValues(product.Options, variant.OptionValues); err != nil {
+		return err
+	}
+
+	existing, err := storage.StorageInstance.Variant.GetVariantBySku(variant.Sku, nil)
+	if err == nil && existing.Id != variant.Id {
+		return status.Errorf(codes.AlreadyExists, "sku %s is already used", variant.Sku)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get variant: %w", err)
+	}
+
+	for _, other := range product.Variants {
+		if other.Id != variant.Id && slices.Equal(other.OptionValues, variant.OptionValues) {
+			return status.Errorf(codes.AlreadyExists, "variant %s already has the options %s", other.Sku, strings.Join(variant.OptionValues, "/"))
+		}
+	}
+	return nil
+}
+
+// validateOptionValues checks there is one allowed value for every option of the product
+func validateOptionValues(options []models.ProductOption, values []string) error {
+	if len(values) != len(options) {
+		return status.Errorf(codes.InvalidArgument, "expected %d option values, got %d", len(options), len(values))
+	}
+	for i, option := range options {
+		if !slices.Contains(option.Values, values[i]) {
+			return status.Errorf(codes.InvalidArgument, "%q is not a value of option %s", values[i], option.Name)
+		}
+	}
+	return nil
+}
+
+// setStripePrice gives a variant with its own price a Stripe price in the product's base currency,
+// reusing the existing one when the price did not change.
+func (v *VariantService) setStripePrice(product *models.Product, variant, existing *models.ProductVariant) error {
+	if variant.PriceMinor == 0 {
+		variant.StripePriceId = ""
+		return nil
+	}
+	if existing != nil && existing.PriceMinor == variant.PriceMinor && existing.StripePriceId != "" {
+		variant.StripePriceId = existing.StripePriceId
+		return nil
+	}
+
+	stripePrice, err := NewStripeService().CreateProductPrice(product.StripeProductId, variant.PriceMinor, product.Currency)
+	if err != nil {
+		return err
+	}
+	variant.StripePriceId = stripePrice.ID
+	return nil
+}
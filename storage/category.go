@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"errors"
+	"product/models"
+	pb "product/proto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CategoryInterface interface {
+	CreateCategory(category *models.Category, tx *gorm.DB) (*models.Category, error)
+	UpdateCategory(category *models.Category, tx *gorm.DB) error
+	DeleteCategory(id uint64, tx *gorm.DB) error
+	GetCategory(id uint64, tx *gorm.DB) (*models.Category, error)
+	GetCategoryBySlug(slug string, tx *gorm.DB) (*models.Category, error)
+	ListCategories(parentId *uint64) ([]*models.Category, error)
+	ListDescendantIds(id uint64, tx *gorm.DB) ([]uint64, error)
+	SetProductCategories(productId uint64, categoryIds []uint64, tx *gorm.DB) ([]models.Category, error)
+}
+
+type CategoryDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewCategoryTable(read, write *gorm.DB) CategoryInterface {
+	StorageInstance.AutoMigrate(&models.Category{})
+	return &CategoryDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// CreateCategory implements CategoryInterface.
+func (i *CategoryDB) CreateCategory(category *models.Category, tx *gorm.DB) (*models.Category, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Create(category)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return category, nil
+}
+
+// UpdateCategory implements CategoryInterface.
+// All fields are written, so a category can be moved back to the top level.
+func (i *CategoryDB) UpdateCategory(category *models.Category, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.Category{}).Where("id = ?", category.Id).Updates(map[string]interface{}{
+		"parent_id":      category.ParentId,
+		"name":           category.Name,
+		"slug":           category.Slug,
+		"description":    category.Description,
+		"commission_bps": category.CommissionBps,
+		"updated_at":     time.Now(),
+	})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return errors.New("no category found with the given ID")
+	}
+	return nil
+}
+
+// DeleteCategory implements CategoryInterface.
+// Products are taken out of the category, they are not deleted.
+func (i *CategoryDB) DeleteCategory(id uint64, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	if err := db.Exec("DELETE FROM product_categories WHERE category_id = ?", id).Error; err != nil {
+		return err
+	}
+	return db.Delete(&models.Category{}, "id = ?", id).Error
+}
+
+// GetCategory implements CategoryInterface.
+func (i *CategoryDB) GetCategory(id uint64, tx *gorm.DB) (*models.Category, error) {
+	category := &models.Category{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("id = ?", id).First(category)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return category, nil
+}
+
+// GetCategoryBySlug implements CategoryInterface.
+func (i *CategoryDB) GetCategoryBySlug(slug string, tx *gorm.DB) (*models.Category, error) {
+	category := &models.Category{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("slug = ?", slug).First(category)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return category, nil
+}
+
+// ListCategories implements CategoryInterface.
+// Lists the children of the parent, the top level categories for 0, or every category when parentId is nil.
+func (i *CategoryDB) ListCategories(parentId *uint64) ([]*models.Category, error) {
+	var categories []*models.Category
+	query := i.read.Order("name ASC")
+	if parentId != nil {
+		if *parentId == 0 {
+			query = query.Where("parent_id IS NULL")
+		} else {
+			query = query.Where("parent_id = ?", *parentId)
+		}
+	}
+	if err := query.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// ListDescendantIds implements CategoryInterface.
+// Returns the category itself and every category below it.
+func (i *CategoryDB) ListDescendantIds(id uint64, tx *gorm.DB) ([]uint64, error) {
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+
+	var ids []uint64
+	ret := db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree`, id).Scan(&ids)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return ids, nil
+}
+
+// SetProductCategories implements CategoryInterface.
+// Replaces the categories of the product and returns them.
+func (i *CategoryDB) SetProductCategories(productId uint64, categoryIds []uint64, tx *gorm.DB) ([]models.Category, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	var categories []models.Category
+	if len(categoryIds) > 0 {
+		if err := db.Where("id IN ?", categoryIds).Order("name ASC").Find(&categories).Error; err != nil {
+			return nil, err
+		}
+		if len(categories) != len(categoryIds) {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+
+	if err := db.Model(&models.Product{Id: productId}).Association("Categories").Replace(categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func CategoryDBToGrpc(category *models.Category) *pb.Category {
+	categoryGrpc := &pb.Category{
+		Id:          category.Id,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   category.UpdatedAt.Format(time.RFC3339),
+	}
+	if category.ParentId != nil {
+		categoryGrpc.ParentId = *category.ParentId
+	}
+	if category.CommissionBps != nil {
+		categoryGrpc.CommissionBps = *category.CommissionBps
+	}
+	return categoryGrpc
+}
+
+func CategoryDBsToGrpcs(categories []*models.Category) []*pb.Category {
+	categoriesGrpc := make([]*pb.Category, 0, len(categories))
+	for _, category := range categories {
+		categoriesGrpc = append(categoriesGrpc, CategoryDBToGrpc(category))
+	}
+	return categoriesGrpc
+}
+
+func categoriesDBToGrpc(categories []models.Category) []*pb.Category {
+	categoriesGrpc := make([]*pb.Category, 0, len(categories))
+	for i := range categories {
+		categoriesGrpc = append(categoriesGrpc, CategoryDBToGrpc(&categories[i]))
+	}
+	return categoriesGrpc
+}
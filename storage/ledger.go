@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"product/models"
+	pb "product/proto"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LedgerInterface interface {
+	CreateEntries(entries []*models.LedgerEntry, tx *gorm.DB) error
+	ListByOrderId(orderId uint64, tx *gorm.DB) ([]*models.LedgerEntry, error)
+}
+
+type LedgerDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewLedgerTable(read, write *gorm.DB) LedgerInterface {
+	StorageInstance.AutoMigrate(&models.LedgerEntry{})
+	return &LedgerDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// CreateEntries implements LedgerInterface.
+func (i *LedgerDB) CreateEntries(entries []*models.LedgerEntry, tx *gorm.DB) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	// An entry whose key was recorded before is skipped
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(entries).Error
+}
+
+// ListByOrderId implements LedgerInterface.
+func (i *LedgerDB) ListByOrderId(orderId uint64, tx *gorm.DB) ([]*models.LedgerEntry, error) {
+	var entries []*models.LedgerEntry
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("order_id = ?", orderId).Order("id ASC").Find(&entries)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return entries, nil
+}
+
+func LedgerEntriesDBToGrpc(entries []*models.LedgerEntry) []*pb.LedgerEntry {
+	var entriesGrpc []*pb.LedgerEntry
+	for _, entry := range entries {
+		entriesGrpc = append(entriesGrpc, &pb.LedgerEntry{
+			Id:             entry.Id,
+			OrderId:        entry.OrderId,
+			SubOrderId:     entry.SubOrderId,
+			MerchantId:     entry.MerchantId,
+			Type:           string(entry.Type),
+			Amount:         entry.Amount,
+			Currency:       entry.Currency,
+			StripeObjectId: entry.StripeObjectId,
+			CreatedAt:      entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return entriesGrpc
+}
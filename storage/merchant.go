@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"product/models"
+	pb "product/proto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MerchantInterface interface {
+	GetMerchant(id uint64, tx *gorm.DB) (*models.Merchant, error)
+	SaveMerchant(merchant *models.Merchant, tx *gorm.DB) (*models.Merchant, error)
+}
+
+type MerchantDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewMerchantTable(read, write *gorm.DB) MerchantInterface {
+	StorageInstance.AutoMigrate(&models.Merchant{})
+	return &MerchantDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// GetMerchant implements MerchantInterface.
+func (i *MerchantDB) GetMerchant(id uint64, tx *gorm.DB) (*models.Merchant, error) {
+	merchant := &models.Merchant{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("id = ?", id).First(merchant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return merchant, nil
+}
+
+// SaveMerchant implements MerchantInterface.
+func (i *MerchantDB) SaveMerchant(merchant *models.Merchant, tx *gorm.DB) (*models.Merchant, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	ret := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"stripe_account_id", "commission_bps", "updated_at"}),
+	}).Create(merchant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return merchant, nil
+}
+
+func MerchantDBToGrpc(merchant *models.Merchant, defaultCommissionBps uint32) *pb.Merchant {
+	commissionBps := defaultCommissionBps
+	if merchant.CommissionBps != nil {
+		commissionBps = *merchant.CommissionBps
+	}
+	return &pb.Merchant{
+		Id:              merchant.Id,
+		StripeAccountId: merchant.StripeAccountId,
+		CommissionBps:   commissionBps,
+	}
+}
@@ -1,25 +1,101 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"product/models"
+	pb "product/proto"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderInterface interface {
 	CreateOrder(order *models.Order, tx *gorm.DB) (*models.Order, error)
 	UpdateOrder(order *models.Order, tx *gorm.DB) error
+	UpdateOrderItem(item *models.OrderItem, tx *gorm.DB) error
+	GetOrder(id uint64, tx *gorm.DB) (*models.Order, error)
+	GetOrderWithLock(id uint64, tx *gorm.DB) (*models.Order, error)
+	GetOrderByCheckoutSessionId(sessionId string, tx *gorm.DB) (*models.Order, error)
+	GetOrderByIdempotencyKey(userId uint64, key string, tx *gorm.DB) (*models.Order, error)
+	LockIdempotencyKey(userId uint64, key string, tx *gorm.DB) error
+	AssignSubOrderItems(orderId, subOrderId, merchantId uint64, tx *gorm.DB) error
+	ListStaleOrders(status models.OrderStatus, before time.Time, limit int) ([]*models.Order, error)
+	ListUnsettledOrders(before time.Time, afterId uint64, limit int) ([]*models.Order, error)
+	MarkSettled(id uint64, settledAt time.Time, tx *gorm.DB) error
+	ListByUserId(userId uint64, filter OrderFilter, limit uint64, cursorID uint64) ([]*models.Order, uint64, uint64, error)
+	ListByMerchantId(merchantId uint64, filter OrderFilter, limit uint64, cursorID uint64) ([]*models.Order, uint64, uint64, error)
+}
+
+// OrderFilter narrows order listings, empty fields are not filtered on
+type OrderFilter struct {
+	Status        models.OrderStatus
+	PaymentStatus models.PaymentStatus
+}
+
+func (f OrderFilter) apply(db *gorm.DB) *gorm.DB {
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	if f.PaymentStatus != "" {
+		db = db.Where("payment_status = ?", f.PaymentStatus)
+	}
+	return db
 }
 
 type OrderDB struct {
+	read  *gorm.DB
 	write *gorm.DB
 }
 
-func NewOrderTable(write *gorm.DB) OrderInterface {
+func NewOrderTable(read, write *gorm.DB) OrderInterface {
+	paymentStatuses := make([]string, 0, len(models.PaymentStatuses))
+	for _, paymentStatus := range models.PaymentStatuses {
+		paymentStatuses = append(paymentStatuses, string(paymentStatus))
+	}
+	StorageInstance.CreateEnum("payment_status", paymentStatuses...)
 	StorageInstance.AutoMigrate(&models.Order{})
-	return &OrderDB{
+	StorageInstance.AutoMigrate(&models.OrderItem{})
+	StorageInstance.MigratePrimaryKey("order_items", "order_id", "product_id", "variant_id")
+	StorageInstance.MigrateToMinorUnits(&models.Order{}, "total", "total_minor")
+	StorageInstance.MigrateToMinorUnits(&models.OrderItem{}, "price", "price_minor")
+	StorageInstance.MigrateCurrency(&models.Order{})
+	orderDB := &OrderDB{
+		read:  read,
 		write: write,
 	}
+	orderDB.migrateSubOrders()
+	return orderDB
+}
+
+// migrateSubOrders splits orders placed before merchant sub-orders existed.
+// Only orders without any sub-order are touched, so it is safe to run on every start.
+func (i *OrderDB) migrateSubOrders() {
+	err := i.write.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO orders (parent_id, merchant_id, user_id, total_minor, currency, status, payment_status, address, created_at, updated_at)
+			SELECT o.id, p.merchant_id, o.user_id, SUM(oi.price_minor * oi.quantity), o.currency, o.status, o.payment_status, o.address, o.created_at, NOW()
+			FROM orders o
+			JOIN order_items oi ON oi.order_id = o.id
+			JOIN products p ON p.id = oi.product_id
+			WHERE o.parent_id IS NULL
+			AND NOT EXISTS (SELECT 1 FROM orders s WHERE s.parent_id = o.id)
+			GROUP BY o.id, p.merchant_id`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			UPDATE order_items oi SET sub_order_id = s.id
+			FROM orders s, products p
+			WHERE oi.sub_order_id IS NULL
+			AND s.parent_id = oi.order_id
+			AND p.id = oi.product_id
+			AND s.merchant_id = p.merchant_id`).Error
+	})
+	if err != nil {
+		log.Printf("failed to migrate sub-orders: %v", err)
+	}
 }
 
 func (i *OrderDB) CreateOrder(order *models.Order, tx *gorm.DB) (*models.Order, error) {
@@ -43,10 +119,290 @@ func (i *OrderDB) UpdateOrder(order *models.Order, tx *gorm.DB) error {
 		db = i.write
 	}
 
-	// Perform the create operation
-	ret := db.Model(&models.Order{}).Where("id = ?", order.Id).Updates(&order)
+	// Perform the update operation, associations are updated through their own methods
+	ret := db.Model(&models.Order{}).Where("id = ?", order.Id).Omit(clause.Associations).Updates(&order)
 	if ret.Error != nil {
 		return ret.Error
 	}
 	return nil
 }
+
+// UpdateOrderItem implements OrderInterface.
+// Only the refunded, shipped and returned quantities of an item may change after the order is placed.
+func (i *OrderDB) UpdateOrderItem(item *models.OrderItem, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.OrderItem{}).Where("order_id = ?", item.OrderId).Where("product_id = ?", item.ProductId).Where("variant_id = ?", item.VariantId).
+		Updates(map[string]interface{}{
+			"refunded_quantity": item.RefundedQuantity,
+			"shipped_quantity":  item.ShippedQuantity,
+			"returned_quantity": item.ReturnedQuantity,
+		})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return errors.New("no order item found with the given ID")
+	}
+	return nil
+}
+
+// GetOrder implements OrderInterface.
+func (i *OrderDB) GetOrder(id uint64, tx *gorm.DB) (*models.Order, error) {
+	order := &models.Order{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Preload("OrderItems.Product").Preload("SubOrderItems.Product").Preload("Shipments.Items").
+		Preload("SubOrders.SubOrderItems.Product").Preload("SubOrders.Shipments.Items").
+		Where("id = ?", id).First(order)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return order, nil
+}
+
+// GetOrderWithLock implements OrderInterface.
+// Locks the order row so concurrent status transitions are serialised.
+func (i *OrderDB) GetOrderWithLock(id uint64, tx *gorm.DB) (*models.Order, error) {
+	order := &models.Order{}
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	ret := tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Preload("OrderItems").Preload("SubOrders.Shipments").Where("id = ?", id).First(order)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return order, nil
+}
+
+// GetOrderByCheckoutSessionId implements OrderInterface.
+func (i *OrderDB) GetOrderByCheckoutSessionId(sessionId string, tx *gorm.DB) (*models.Order, error) {
+	order := &models.Order{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("checkout_session_id = ?", sessionId).First(order)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return order, nil
+}
+
+// GetOrderByIdempotencyKey implements OrderInterface.
+func (i *OrderDB) GetOrderByIdempotencyKey(userId uint64, key string, tx *gorm.DB) (*models.Order, error) {
+	order := &models.Order{}
+	db := tx
+	if db == nil {
+		db = i.write // read replica may lag behind the order that was just placed
+	}
+	ret := db.Where("user_id = ?", userId).Where("idempotency_key = ?", key).First(order)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return order, nil
+}
+
+// LockIdempotencyKey implements OrderInterface.
+// Takes a transaction scoped advisory lock so concurrent requests with the same key are serialised.
+func (i *OrderDB) LockIdempotencyKey(userId uint64, key string, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", fmt.Sprintf("order:%d:%s", userId, key)).Error
+}
+
+// AssignSubOrderItems implements OrderInterface.
+// Attaches the order items of the merchant's products to the merchant's sub-order.
+func (i *OrderDB) AssignSubOrderItems(orderId, subOrderId, merchantId uint64, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	merchantProducts := db.Model(&models.Product{}).Select("id").Where("merchant_id = ?", merchantId)
+	return db.Model(&models.OrderItem{}).Where("order_id = ?", orderId).Where("product_id IN (?)", merchantProducts).
+		Update("sub_order_id", subOrderId).Error
+}
+
+// ListStaleOrders implements OrderInterface.
+// Returns buyer orders that have been in the given status since before the cutoff, oldest first.
+// Orders waiting for a delayed payment are left out, the payment webhooks settle them.
+func (i *OrderDB) ListStaleOrders(status models.OrderStatus, before time.Time, limit int) ([]*models.Order, error) {
+	var orders []*models.Order
+	ret := i.write.Where("parent_id IS NULL").Where("status = ?", status).Where("payment_status <> ?", models.PaymentStatusProcessing).
+		Where("created_at < ?", before).Order("created_at ASC").Limit(limit).Find(&orders)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return orders, nil
+}
+
+// ListUnsettledOrders implements OrderInterface.
+// Lists paid buyer orders that were last updated before the given time and whose merchants were not settled yet,
+// by ID starting after afterId.
+func (i *OrderDB) ListUnsettledOrders(before time.Time, afterId uint64, limit int) ([]*models.Order, error) {
+	var orders []*models.Order
+	paid := []models.PaymentStatus{models.PaymentStatusCompleted, models.PaymentStatusPartiallyRefunded, models.PaymentStatusRefunded}
+	ret := i.write.Where("parent_id IS NULL").Where("settled_at IS NULL").Where("payment_status IN ?", paid).
+		Where("updated_at < ?", before).Where("id > ?", afterId).Order("id ASC").Limit(limit).Find(&orders)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return orders, nil
+}
+
+// MarkSettled implements OrderInterface.
+func (i *OrderDB) MarkSettled(id uint64, settledAt time.Time, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.Order{}).Where("id = ?", id).UpdateColumn("settled_at", settledAt)
+	if ret.Error != nil {
+		return ret.Error
+	}
+	return nil
+}
+
+// ListByUserId implements OrderInterface.
+// Lists the buyer's orders together with their merchant sub-orders.
+func (i *OrderDB) ListByUserId(userId uint64, filter OrderFilter, limit uint64, cursorID uint64) ([]*models.Order, uint64, uint64, error) {
+	var orders []*models.Order
+
+	query := filter.apply(i.read.Preload("OrderItems.Product").Preload("SubOrders.SubOrderItems.Product").Preload("SubOrders.Shipments.Items").
+		Order("id ASC").Where("parent_id IS NULL").Where("user_id = ?", userId).Limit(int(limit)))
+	// Count the total number of orders
+	var totalOrders int64
+	if err := filter.apply(i.read.Model(&models.Order{}).Where("parent_id IS NULL").Where("user_id = ?", userId)).Count(&totalOrders).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Apply cursor condition if provided
+	if cursorID > 0 {
+		query = query.Where("id > ?", cursorID)
+	}
+
+	// Fetch the orders
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Get the last order's ID as the next cursor
+	var nextCursor uint64
+	if len(orders) > 0 {
+		nextCursor = orders[len(orders)-1].Id
+	}
+
+	return orders, nextCursor, uint64(totalOrders), nil
+}
+
+// ListByMerchantId implements OrderInterface.
+// Lists the merchant's sub-orders, which only contain the merchant's own lines.
+func (i *OrderDB) ListByMerchantId(merchantId uint64, filter OrderFilter, limit uint64, cursorID uint64) ([]*models.Order, uint64, uint64, error) {
+	var orders []*models.Order
+
+	query := filter.apply(i.read.Preload("SubOrderItems.Product").Preload("Shipments.Items").
+		Order("id ASC").Where("parent_id IS NOT NULL").Where("merchant_id = ?", merchantId).Limit(int(limit)))
+	// Count the total number of orders
+	var totalOrders int64
+	if err := filter.apply(i.read.Model(&models.Order{}).Where("parent_id IS NOT NULL").Where("merchant_id = ?", merchantId)).Count(&totalOrders).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Apply cursor condition if provided
+	if cursorID > 0 {
+		query = query.Where("id > ?", cursorID)
+	}
+
+	// Fetch the orders
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Get the last order's ID as the next cursor
+	var nextCursor uint64
+	if len(orders) > 0 {
+		nextCursor = orders[len(orders)-1].Id
+	}
+
+	return orders, nextCursor, uint64(totalOrders), nil
+}
+
+func OrderDBToGrpc(order *models.Order) *pb.Order {
+	orderItems := make([]*pb.OrderItem, 0, len(order.Items()))
+	for _, item := range order.Items() {
+		orderItem := &pb.OrderItem{
+			OrderId:   item.OrderId,
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Sku:       item.Sku,
+			Quantity:  item.Quantity,
+			Price:     models.FromMinorUnits(item.PriceMinor, order.Currency),
+			CreatedAt: item.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: item.UpdatedAt.Format(time.RFC3339),
+
+			RefundedQuantity: item.RefundedQuantity,
+			ShippedQuantity:  item.ShippedQuantity,
+			ReturnedQuantity: item.ReturnedQuantity,
+			PriceMinor:       item.PriceMinor,
+			Currency:         order.Currency,
+		}
+		if item.Product != nil {
+			orderItem.ProductName = item.Product.Name
+			if len(item.Product.Images) > 0 {
+				orderItem.ProductImage = item.Product.Images[0]
+			}
+		}
+		orderItems = append(orderItems, orderItem)
+	}
+
+	var parentId uint64
+	if order.ParentId != nil {
+		parentId = *order.ParentId
+	}
+	subOrders := make([]*pb.Order, 0, len(order.SubOrders))
+	for i := range order.SubOrders {
+		subOrders = append(subOrders, OrderDBToGrpc(&order.SubOrders[i]))
+	}
+	shipments := make([]*pb.Shipment, 0, len(order.Shipments))
+	for i := range order.Shipments {
+		shipments = append(shipments, ShipmentDBToGrpc(&order.Shipments[i]))
+	}
+
+	return &pb.Order{
+		Id:                order.Id,
+		ParentId:          parentId,
+		MerchantId:        order.MerchantId,
+		SubOrders:         subOrders,
+		Shipments:         shipments,
+		UserId:            order.UserId,
+		Total:             models.FromMinorUnits(order.TotalMinor, order.Currency),
+		TotalMinor:        order.TotalMinor,
+		Currency:          order.Currency,
+		Status:            string(order.Status),
+		TransactionId:     order.TransactionId,
+		CheckoutSessionId: order.CheckoutSessionId,
+		PaymentStatus:     string(order.PaymentStatus),
+		OrderItems:        orderItems,
+		Address:           order.Address,
+		CreatedAt:         order.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         order.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func OrderDBsToGrpcs(orders []*models.Order) []*pb.Order {
+	var ordersGrpc []*pb.Order
+	for _, order := range orders {
+		ordersGrpc = append(ordersGrpc, OrderDBToGrpc(order))
+	}
+	return ordersGrpc
+}
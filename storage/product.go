@@ -1,24 +1,193 @@
 package storage
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
 	"product/models"
 	pb "product/proto"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type ProductInterface interface {
-	CreateProduct(Product *models.Product) (*models.Product, error)
+	CreateProduct(Product *models.Product, tx *gorm.DB) (*models.Product, error)
 	Get(id uint64, tx *gorm.DB) (*models.Product, error)
 	GetWithLock(id uint64, tx *gorm.DB) (*models.Product, error)
+	GetDeletedWithLock(id uint64, tx *gorm.DB) (*models.Product, error)
 	Update(Product *models.Product, tx *gorm.DB) (*models.Product, error)
 	UpdateInventory(id, inventory uint64, tx *gorm.DB) error
-	Delete(id uint64) error
-	List(limit uint64, cursorID uint64) ([]*models.Product, uint64, uint64, error)
-	ListByMerchantId(merchantId uint64, limit uint64, cursorID uint64) ([]*models.Product, uint64, uint64, error)
+	SetStockWithLock(id, inventory uint64, tx *gorm.DB) (uint64, error)
+	RestockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, error)
+	TakeStockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, uint64, error)
+	Delete(id uint64, tx *gorm.DB) error
+	SoftDelete(id uint64, tx *gorm.DB) error
+	Restore(id uint64, tx *gorm.DB) error
+	ListPurgeable(deletedBefore time.Time, limit int) ([]*models.Product, error)
+	List(filter ProductFilter, page ProductPage) ([]*models.Product, string, uint64, error)
+	ListByMerchantId(merchantId uint64, filter ProductFilter, page ProductPage) ([]*models.Product, string, uint64, error)
+	Search(text string, filter ProductFilter, page ProductPage) ([]*models.Product, string, uint64, error)
+	Facets(text string, filter ProductFilter, priceBuckets []int64) ([]ProductFacet, error)
 	UpdateImageUrl(Product *models.Product) (*models.Product, error)
+	SavePrice(price *models.ProductPrice, tx *gorm.DB) error
+	DeletePrice(productId uint64, currency string, tx *gorm.DB) error
+	PublishScheduled(now time.Time) (int64, error)
+	SaveSpecifications(productId uint64, specs models.ProductSpecifications, attributes []models.ProductAttribute, tx *gorm.DB) error
+}
+
+// ProductFilter narrows product listings, empty fields are not filtered on
+type ProductFilter struct {
+	MerchantId     uint64
+	CategoryIds    []uint64 // Products in any of the categories
+	Currency       string   // Currency of the price range, and of sorting by price
+	MinPriceMinor  int64
+	MaxPriceMinor  int64
+	InStock        bool // Products with stock of their own or in any of their variants
+	CreatedAfter   time.Time
+	Attributes     []AttributeFilter // Products matching all of them
+	PublishedOnly  bool              // Products buyers can see, including scheduled ones that are due
+	Statuses       []models.ProductStatus
+	IncludeDeleted bool
+}
+
+// AttributeFilter matches the brand, the material or a custom attribute of a product.
+// Values match any of them, Min and Max bound number attributes and are ignored when 0.
+type AttributeFilter struct {
+	Key    string
+	Values []string
+	Min    float64
+	Max    float64
+}
+
+// specificationColumns are the standard specifications that can be filtered like attributes
+var specificationColumns = map[string]string{
+	"brand":    "products.brand",
+	"material": "products.material",
+}
+
+func (f ProductFilter) apply(db *gorm.DB) *gorm.DB {
+	if !f.IncludeDeleted {
+		db = db.Where("products.is_deleted = false")
+	}
+	if f.MerchantId != 0 {
+		db = db.Where("products.merchant_id = ?", f.MerchantId)
+	}
+	if f.PublishedOnly {
+		db = db.Where("(products.status = ? OR (products.status = ? AND products.publish_at <= NOW()))",
+			models.ProductStatusPublished, models.ProductStatusScheduled)
+	}
+	if len(f.Statuses) > 0 {
+		db = db.Where("products.status IN ?", f.Statuses)
+	}
+	if len(f.CategoryIds) > 0 {
+		db = db.Where("products.id IN (SELECT product_id FROM product_categories WHERE category_id IN ?)", f.CategoryIds)
+	}
+	if f.MinPriceMinor > 0 {
+		db = db.Where(priceIn(f.Currency, ">= ?", f.MinPriceMinor))
+	}
+	if f.MaxPriceMinor > 0 {
+		db = db.Where(priceIn(f.Currency, "<= ?", f.MaxPriceMinor))
+	}
+	if f.InStock {
+		db = db.Where("(products.inventory > 0 OR EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = products.id AND v.is_deleted = false AND v.inventory > 0))")
+	}
+	if !f.CreatedAfter.IsZero() {
+		db = db.Where("products.created_at > ?", f.CreatedAfter)
+	}
+	for _, attribute := range f.Attributes {
+		if column, ok := specificationColumns[attribute.Key]; ok {
+			if len(attribute.Values) > 0 {
+				db = db.Where(column+" IN ?", attribute.Values)
+			}
+			continue
+		}
+
+		sql := "EXISTS (SELECT 1 FROM product_attributes pa WHERE pa.product_id = products.id AND pa.key = ?"
+		vars := []interface{}{attribute.Key}
+		if len(attribute.Values) > 0 {
+			sql += " AND pa.value IN ?"
+			vars = append(vars, attribute.Values)
+		}
+		if attribute.Min != 0 {
+			sql += " AND pa.number_value >= ?"
+			vars = append(vars, attribute.Min)
+		}
+		if attribute.Max != 0 {
+			sql += " AND pa.number_value <= ?"
+			vars = append(vars, attribute.Max)
+		}
+		db = db.Where(sql+")", vars...)
+	}
+	return db
+}
+
+// priceIn compares the product's price in the currency, which is NULL for products not sold in it
+func priceIn(currency, comparison string, vars ...interface{}) clause.Expr {
+	return clause.Expr{
+		SQL: `COALESCE(CASE WHEN products.currency = ? THEN products.price_minor END,
+			(SELECT pp.price_minor FROM product_prices pp WHERE pp.product_id = products.id AND pp.currency = ?)) ` + comparison,
+		Vars:               append([]interface{}{currency, currency}, vars...),
+		WithoutParentheses: true,
+	}
+}
+
+// ProductSort orders product listings, ties are broken by product ID so pages never overlap
+type ProductSort string
+
+const (
+	ProductSortId        ProductSort = "id"
+	ProductSortNewest    ProductSort = "newest"
+	ProductSortPriceAsc  ProductSort = "price_asc"
+	ProductSortPriceDesc ProductSort = "price_desc"
+	ProductSortName      ProductSort = "name"
+	productSortRelevance ProductSort = "relevance"
+)
+
+// ProductSorts are the orders product listings can be sorted in
+var ProductSorts = []ProductSort{ProductSortId, ProductSortNewest, ProductSortPriceAsc, ProductSortPriceDesc, ProductSortName}
+
+// ProductPage selects a page of a product listing
+type ProductPage struct {
+	Sort   ProductSort
+	Limit  uint64
+	Token  string // Returned with the previous page, empty for the first page
+	Cursor uint64 // Deprecated raw cursor, the last product ID when sorted by ID, used when Token is empty
+}
+
+// ErrInvalidPageToken is returned for page tokens of another listing order or that were tampered with
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// productCursor is the position after the last product of a page, encoded into an opaque page token
+type productCursor struct {
+	Sort     ProductSort `json:"s"`
+	Currency string      `json:"c,omitempty"`
+	Value    string      `json:"v,omitempty"`
+	Id       uint64      `json:"i,omitempty"`
+}
+
+func (c productCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeProductCursor(token string) (productCursor, error) {
+	var cursor productCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, ErrInvalidPageToken
+	}
+	return cursor, nil
 }
 
 type ProductDB struct {
@@ -27,8 +196,12 @@ type ProductDB struct {
 }
 
 // CreateProduct implements ProductInterface.
-func (i *ProductDB) CreateProduct(Product *models.Product) (*models.Product, error) {
-	ret := i.write.Create(Product)
+func (i *ProductDB) CreateProduct(Product *models.Product, tx *gorm.DB) (*models.Product, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	ret := db.Create(Product)
 	if ret.Error != nil {
 		return nil, ret.Error
 	}
@@ -36,8 +209,76 @@ func (i *ProductDB) CreateProduct(Product *models.Product) (*models.Product, err
 }
 
 // Delete implements ProductInterface.
-func (i *ProductDB) Delete(id uint64) error {
-	return i.write.Delete(&models.Product{}, "id = ?", id).Error
+// Permanently removes a soft deleted product along with its prices, options, variants, attributes and categories.
+// Returns gorm.ErrRecordNotFound when the product is not deleted, e.g. because it was restored in the meantime.
+func (i *ProductDB) Delete(id uint64, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+
+	for _, model := range []interface{}{&models.ProductPrice{}, &models.ProductOption{}, &models.ProductVariant{}, &models.ProductAttribute{}} {
+		if err := tx.Where("product_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Exec("DELETE FROM product_categories WHERE product_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	ret := tx.Where("id = ?", id).Where("is_deleted = true").Delete(&models.Product{})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SoftDelete implements ProductInterface.
+// Hides the product, it can be restored until it is purged.
+func (i *ProductDB) SoftDelete(id uint64, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Model(&models.Product{}).Where("id = ?", id).Where("is_deleted = false").Updates(map[string]interface{}{
+		"is_deleted": true,
+		"deleted_at": time.Now(),
+	}).Error
+}
+
+// Restore implements ProductInterface.
+func (i *ProductDB) Restore(id uint64, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.Product{}).Where("id = ?", id).Where("is_deleted = true").Updates(map[string]interface{}{
+		"is_deleted": false,
+		"deleted_at": nil,
+	})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListPurgeable implements ProductInterface.
+// Lists products deleted before the time that no order item refers to, oldest deletions first.
+func (i *ProductDB) ListPurgeable(deletedBefore time.Time, limit int) ([]*models.Product, error) {
+	var products []*models.Product
+	ret := i.write.Where("is_deleted = true AND deleted_at <= ?", deletedBefore).
+		Where("NOT EXISTS (SELECT 1 FROM order_items oi WHERE oi.product_id = products.id)").
+		Order("deleted_at ASC").Limit(limit).Find(&products)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return products, nil
 }
 
 // Get implements ProductInterface.
@@ -47,7 +288,7 @@ func (i *ProductDB) Get(id uint64, tx *gorm.DB) (*models.Product, error) {
 	if db == nil {
 		db = i.read
 	}
-	ret := db.Where("id = ?", id).Where("is_deleted = false").First(Product)
+	ret := preloadProduct(db).Where("id = ?", id).Where("is_deleted = false").First(Product)
 	if ret.Error != nil {
 		return nil, ret.Error
 	}
@@ -58,13 +299,29 @@ func (i *ProductDB) Get(id uint64, tx *gorm.DB) (*models.Product, error) {
 // This function is used to get a product with a lock.
 // Preparation to update an inventory.
 func (i *ProductDB) GetWithLock(id uint64, tx *gorm.DB) (*models.Product, error) {
+	Product := &models.Product{}
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	ret := preloadProduct(tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	})).Where("id = ?", id).Where("is_deleted = false").First(Product)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return Product, nil
+}
+
+// GetDeletedWithLock implements ProductInterface.
+// Gets a soft deleted product with a lock, to restore or purge it.
+func (i *ProductDB) GetDeletedWithLock(id uint64, tx *gorm.DB) (*models.Product, error) {
 	Product := &models.Product{}
 	if tx == nil {
 		return nil, errors.New("transaction is required")
 	}
 	ret := tx.Clauses(clause.Locking{
 		Strength: "UPDATE",
-	}).Where("id = ?", id).Where("is_deleted = false").First(Product)
+	}).Where("id = ?", id).Where("is_deleted = true").First(Product)
 	if ret.Error != nil {
 		return nil, ret.Error
 	}
@@ -78,7 +335,7 @@ func (i *ProductDB) Update(Product *models.Product, tx *gorm.DB) (*models.Produc
 	if db == nil {
 		db = i.write
 	}
-	result := db.Model(&models.Product{}).Where("id = ?", Product.Id).Updates(Product)
+	result := db.Model(&models.Product{}).Where("id = ?", Product.Id).Omit(clause.Associations).Updates(Product)
 
 	if result.Error != nil {
 		return nil, result.Error // Return the actual error
@@ -111,62 +368,411 @@ func (i *ProductDB) UpdateInventory(id, inventory uint64, tx *gorm.DB) error {
 	return nil
 }
 
-func (i *ProductDB) List(limit uint64, cursorID uint64) ([]*models.Product, uint64, uint64, error) {
+// SetStockWithLock implements ProductInterface.
+// Locks the product row, sets its inventory and returns the previous one.
+func (i *ProductDB) SetStockWithLock(id, inventory uint64, tx *gorm.DB) (uint64, error) {
+	Product := &models.Product{}
+	if tx == nil {
+		return 0, errors.New("transaction is required")
+	}
+	ret := tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Where("id = ?", id).First(Product)
+	if ret.Error != nil {
+		return 0, ret.Error
+	}
+
+	return Product.Inventory, i.UpdateInventory(id, inventory, tx)
+}
+
+// RestockWithLock implements ProductInterface.
+// Locks the product row, adds the quantity back to its inventory and returns the new inventory.
+// Deleted products are restocked as well so a restore brings back the correct stock.
+func (i *ProductDB) RestockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, error) {
+	Product := &models.Product{}
+	if tx == nil {
+		return 0, errors.New("transaction is required")
+	}
+	ret := tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Where("id = ?", id).First(Product)
+	if ret.Error != nil {
+		return 0, ret.Error
+	}
+
+	inventory := Product.Inventory + quantity
+	return inventory, i.UpdateInventory(id, inventory, tx)
+}
+
+// TakeStockWithLock implements ProductInterface.
+// Locks the product row and takes the quantity out of its inventory, without going below zero.
+// Returns the new inventory and how much of the quantity was missing, which is only above zero when stock was oversold.
+// Deleted products are included, a product may be deleted while its order is being paid.
+func (i *ProductDB) TakeStockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, uint64, error) {
+	Product := &models.Product{}
+	if tx == nil {
+		return 0, 0, errors.New("transaction is required")
+	}
+	ret := tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Where("id = ?", id).First(Product)
+	if ret.Error != nil {
+		return 0, 0, ret.Error
+	}
+
+	if Product.Inventory < quantity {
+		return 0, quantity - Product.Inventory, i.UpdateInventory(id, 0, tx)
+	}
+	inventory := Product.Inventory - quantity
+	return inventory, 0, i.UpdateInventory(id, inventory, tx)
+}
+
+// List implements ProductInterface.
+// Pages are keyed on the sort value and ID of the last product, so they stay stable while products are added.
+// Returns the products, the token of the next page, empty on the last page, and the total number of matches.
+func (i *ProductDB) List(filter ProductFilter, page ProductPage) ([]*models.Product, string, uint64, error) {
 	var products []*models.Product
+	if page.Sort == "" {
+		page.Sort = ProductSortId
+	}
 
-	query := i.read.Order("id ASC").Where("is_deleted = false").Limit(int(limit))
 	// Count the total number of products
 	var totalProducts int64
-	if err := i.read.Model(&models.Product{}).Where("is_deleted = false").Distinct("id").Where("is_deleted = false").Count(&totalProducts).Error; err != nil {
-		return nil, 0, 0, err
+	if err := filter.apply(i.read.Model(&models.Product{})).Count(&totalProducts).Error; err != nil {
+		return nil, "", 0, err
 	}
 
+	query := filter.apply(preloadProduct(i.read))
+
 	// Apply cursor condition if provided
-	if cursorID > 0 {
-		query = query.Where("id > ?", cursorID)
+	cursor := productCursor{Sort: page.Sort, Id: page.Cursor}
+	if page.Token != "" {
+		var err error
+		if cursor, err = decodeProductCursor(page.Token); err != nil {
+			return nil, "", 0, err
+		}
+		if cursor.Sort != page.Sort || cursor.Currency != filter.sortCurrency(page.Sort) {
+			return nil, "", 0, ErrInvalidPageToken
+		}
 	}
 
-	// Fetch the products
-	if err := query.Find(&products).Error; err != nil {
-		return nil, 0, 0, err
+	switch page.Sort {
+	case ProductSortId:
+		if cursor.Id > 0 {
+			query = query.Where("products.id > ?", cursor.Id)
+		}
+		query = query.Order("products.id ASC")
+	case ProductSortNewest:
+		if page.Token != "" {
+			createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+			if err != nil {
+				return nil, "", 0, ErrInvalidPageToken
+			}
+			query = query.Where("(products.created_at, products.id) < (?, ?)", createdAt, cursor.Id)
+		}
+		query = query.Order("products.created_at DESC").Order("products.id DESC")
+	case ProductSortPriceAsc, ProductSortPriceDesc:
+		comparison, direction := ">", "ASC"
+		if page.Sort == ProductSortPriceDesc {
+			comparison, direction = "<", "DESC"
+		}
+		query = query.Where(priceIn(filter.Currency, "IS NOT NULL"))
+		if page.Token != "" {
+			price, err := strconv.ParseInt(cursor.Value, 10, 64)
+			if err != nil {
+				return nil, "", 0, ErrInvalidPageToken
+			}
+			query = query.Where(clause.Expr{
+				SQL:  "(?, products.id) " + comparison + " (?, ?)",
+				Vars: []interface{}{priceIn(filter.Currency, ""), price, cursor.Id},
+			})
+		}
+		query = query.Order(clause.OrderBy{Expression: priceIn(filter.Currency, direction)}).Order("products.id " + direction)
+	case ProductSortName:
+		if page.Token != "" {
+			query = query.Where("(products.name, products.id) > (?, ?)", cursor.Value, cursor.Id)
+		}
+		query = query.Order("products.name ASC").Order("products.id ASC")
+	default:
+		return nil, "", 0, fmt.Errorf("unknown product sort %q", page.Sort)
 	}
 
-	// Get the last product's ID as the next cursor
-	var nextCursor uint64
-	if len(products) > 0 {
-		nextCursor = products[len(products)-1].Id
+	// Fetch one product more than the page to know whether there is a next page
+	if err := query.Limit(int(page.Limit) + 1).Find(&products).Error; err != nil {
+		return nil, "", 0, err
+	}
+	if uint64(len(products)) <= page.Limit {
+		return products, "", uint64(totalProducts), nil
+	}
+	products = products[:page.Limit]
+	if len(products) == 0 {
+		return products, "", uint64(totalProducts), nil
 	}
 
-	return products, nextCursor, uint64(totalProducts), nil
+	// Get the last product's sort value and ID as the next cursor
+	last := products[len(products)-1]
+	next := productCursor{Sort: page.Sort, Currency: filter.sortCurrency(page.Sort), Id: last.Id}
+	switch page.Sort {
+	case ProductSortNewest:
+		next.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	case ProductSortPriceAsc, ProductSortPriceDesc:
+		price, _, _ := last.PriceIn(filter.Currency)
+		next.Value = strconv.FormatInt(price, 10)
+	case ProductSortName:
+		next.Value = last.Name
+	}
+	return products, next.encode(), uint64(totalProducts), nil
 }
 
-func (i *ProductDB) ListByMerchantId(merchantId uint64, limit uint64, cursorID uint64) ([]*models.Product, uint64, uint64, error) {
+// sortCurrency is the currency the sort order depends on, only sorting by price does
+func (f ProductFilter) sortCurrency(sort ProductSort) string {
+	if sort == ProductSortPriceAsc || sort == ProductSortPriceDesc {
+		return f.Currency
+	}
+	return ""
+}
+
+// ListByMerchantId implements ProductInterface.
+func (i *ProductDB) ListByMerchantId(merchantId uint64, filter ProductFilter, page ProductPage) ([]*models.Product, string, uint64, error) {
+	filter.MerchantId = merchantId
+	return i.List(filter, page)
+}
+
+// Search implements ProductInterface.
+// Matches the words of the text, the last one as a prefix, against the name and description, best matches first.
+// Relevance can not be paged by key, so page tokens hold the offset of the next page.
+func (i *ProductDB) Search(text string, filter ProductFilter, page ProductPage) ([]*models.Product, string, uint64, error) {
 	var products []*models.Product
 
-	query := i.read.Order("id ASC").Where("is_deleted = false").Where("merchant_id = ?", merchantId).Limit(int(limit))
-	// Count the total number of products
-	var totalProducts int64
-	if err := i.read.Model(&models.Product{}).Where("is_deleted = false").Where("merchant_id = ?", merchantId).Distinct("id").Count(&totalProducts).Error; err != nil {
-		return nil, 0, 0, err
+	offset := page.Cursor
+	if page.Token != "" {
+		cursor, err := decodeProductCursor(page.Token)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if cursor.Sort != productSortRelevance {
+			return nil, "", 0, ErrInvalidPageToken
+		}
+		if offset, err = strconv.ParseUint(cursor.Value, 10, 64); err != nil {
+			return nil, "", 0, ErrInvalidPageToken
+		}
 	}
 
-	// Apply cursor condition if provided
-	if cursorID > 0 {
-		query = query.Where("id > ?", cursorID)
+	match, rank, totalProducts, err := i.searchMatch(text, filter)
+	if err != nil {
+		return nil, "", 0, err
 	}
 
-	// Fetch the products
+	query := filter.apply(preloadProduct(i.read)).Where(match).
+		Order(clause.OrderBy{Expression: rank}).Order("products.id ASC").
+		Offset(int(offset)).Limit(int(page.Limit))
 	if err := query.Find(&products).Error; err != nil {
-		return nil, 0, 0, err
+		return nil, "", 0, err
+	}
+
+	next := offset + uint64(len(products))
+	if next >= uint64(totalProducts) || len(products) == 0 {
+		return products, "", uint64(totalProducts), nil
+	}
+	return products, productCursor{Sort: productSortRelevance, Value: strconv.FormatUint(next, 10)}.encode(), uint64(totalProducts), nil
+}
+
+// searchMatch returns the condition matching the text and the order ranking the matches, along with the number of matches.
+// Full-text matches are preferred, when there are none it falls back to names similar to the text,
+// using pg_trgm.word_similarity_threshold, to cope with typos.
+func (i *ProductDB) searchMatch(text string, filter ProductFilter) (clause.Expr, clause.Expr, int64, error) {
+	match := clause.Expr{SQL: "products.search_vector @@ to_tsquery('english', ?)", Vars: []interface{}{searchQuery(text)}}
+	rank := clause.Expr{SQL: "ts_rank(products.search_vector, to_tsquery('english', ?)) DESC", Vars: []interface{}{searchQuery(text)}, WithoutParentheses: true}
+
+	var totalProducts int64
+	if err := filter.apply(i.read.Model(&models.Product{})).Where(match).Count(&totalProducts).Error; err != nil {
+		return match, rank, 0, err
+	}
+	if totalProducts > 0 {
+		return match, rank, totalProducts, nil
+	}
+
+	match = clause.Expr{SQL: "? <% products.name", Vars: []interface{}{text}}
+	rank = clause.Expr{SQL: "word_similarity(?, products.name) DESC", Vars: []interface{}{text}, WithoutParentheses: true}
+	if err := filter.apply(i.read.Model(&models.Product{})).Where(match).Count(&totalProducts).Error; err != nil {
+		return match, rank, 0, err
+	}
+	return match, rank, totalProducts, nil
+}
+
+// searchQuery turns free text into a tsquery matching all of its words, the last one as a prefix
+// so results show up while the buyer is still typing.
+func searchQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
 	}
+	words[len(words)-1] += ":*"
+	return strings.Join(words, " & ")
+}
 
-	// Get the last product's ID as the next cursor
-	var nextCursor uint64
-	if len(products) > 0 {
-		nextCursor = products[len(products)-1].Id
+// migrateSearch maintains the weighted search vector over the name and description,
+// and the indexes used for full-text and trigram search.
+func (i *ProductDB) migrateSearch() {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)`,
+		`CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops)`,
 	}
+	for _, db := range []*gorm.DB{i.write, i.read} {
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				log.Printf("failed to migrate product search: %v", err)
+				break
+			}
+		}
+	}
+}
 
-	return products, nextCursor, uint64(totalProducts), nil
+// ProductFacet counts the products matching a listing per value of a product property
+type ProductFacet struct {
+	Name   string
+	Values []ProductFacetValue
+}
+
+type ProductFacetValue struct {
+	Value string
+	Label string
+	Count uint64
+}
+
+// Facets implements ProductInterface.
+// Counts the products matching the filter, and the search text when it is not empty,
+// per category, merchant, price bucket, brand, material and custom attribute value.
+// Price buckets are split at the given upper bounds in minor units of the filter currency, the last bucket has no upper bound.
+func (i *ProductDB) Facets(text string, filter ProductFilter, priceBuckets []int64) ([]ProductFacet, error) {
+	var match *clause.Expr
+	if text != "" {
+		searchMatch, _, _, err := i.searchMatch(text, filter)
+		if err != nil {
+			return nil, err
+		}
+		match = &searchMatch
+	}
+	matching := func() *gorm.DB {
+		db := filter.apply(i.read.Model(&models.Product{}))
+		if match != nil {
+			db = db.Where(*match)
+		}
+		return db
+	}
+
+	var categories []ProductFacetValue
+	if err := matching().
+		Joins("JOIN product_categories pc ON pc.product_id = products.id").
+		Joins("JOIN categories c ON c.id = pc.category_id").
+		Select("c.id::text AS value, c.name AS label, COUNT(*) AS count").
+		Group("c.id, c.name").Order("count DESC").Order("c.name ASC").
+		Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	var merchants []ProductFacetValue
+	if err := matching().
+		Select("products.merchant_id::text AS value, COUNT(*) AS count").
+		Group("products.merchant_id").Order("count DESC").Order("products.merchant_id ASC").
+		Scan(&merchants).Error; err != nil {
+		return nil, err
+	}
+
+	// width_bucket numbers the buckets from 0, below the first bound, to len(priceBuckets), at or above the last one
+	var buckets []struct {
+		Bucket int
+		Count  uint64
+	}
+	if err := matching().
+		Where(priceIn(filter.Currency, "IS NOT NULL")).
+		Select("width_bucket(?, ?::bigint[]) AS bucket, COUNT(*) AS count", priceIn(filter.Currency, ""), pq.Array(priceBuckets)).
+		Group("bucket").Order("bucket ASC").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	prices := make([]ProductFacetValue, 0, len(buckets))
+	for _, bucket := range buckets {
+		var lower, upper string
+		if bucket.Bucket > 0 {
+			lower = strconv.FormatInt(priceBuckets[bucket.Bucket-1], 10)
+		} else {
+			lower = "0"
+		}
+		if bucket.Bucket < len(priceBuckets) {
+			upper = strconv.FormatInt(priceBuckets[bucket.Bucket], 10)
+		}
+		prices = append(prices, ProductFacetValue{Value: lower + "-" + upper, Count: bucket.Count})
+	}
+
+	facets := []ProductFacet{
+		{Name: "category", Values: categories},
+		{Name: "merchant", Values: merchants},
+		{Name: "price", Values: prices},
+	}
+
+	for _, name := range []string{"brand", "material"} {
+		var values []ProductFacetValue
+		column := specificationColumns[name]
+		if err := matching().
+			Where(column + " <> ''").
+			Select(column + " AS value, COUNT(*) AS count").
+			Group(column).Order("count DESC").Order(column + " ASC").
+			Scan(&values).Error; err != nil {
+			return nil, err
+		}
+		facets = append(facets, ProductFacet{Name: name, Values: values})
+	}
+
+	// Number attributes rarely share values, they are filtered by range instead
+	var attributes []struct {
+		Key   string
+		Value string
+		Count uint64
+	}
+	if err := matching().
+		Joins("JOIN product_attributes pa ON pa.product_id = products.id").
+		Where("pa.type <> ?", models.AttributeTypeNumber).
+		Select("pa.key AS key, pa.value AS value, COUNT(*) AS count").
+		Group("pa.key, pa.value").Order("pa.key ASC").Order("count DESC").Order("pa.value ASC").
+		Scan(&attributes).Error; err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		name := "attribute:" + attribute.Key
+		if facets[len(facets)-1].Name != name {
+			facets = append(facets, ProductFacet{Name: name})
+		}
+		last := &facets[len(facets)-1]
+		last.Values = append(last.Values, ProductFacetValue{Value: attribute.Value, Count: attribute.Count})
+	}
+	return facets, nil
+}
+
+func FacetsToGrpc(facets []ProductFacet) []*pb.Facet {
+	facetsGrpc := make([]*pb.Facet, 0, len(facets))
+	for _, facet := range facets {
+		values := make([]*pb.FacetValue, 0, len(facet.Values))
+		for _, value := range facet.Values {
+			values = append(values, &pb.FacetValue{
+				Value: value.Value,
+				Label: value.Label,
+				Count: value.Count,
+			})
+		}
+		facetsGrpc = append(facetsGrpc, &pb.Facet{
+			Name:   facet.Name,
+			Values: values,
+		})
+	}
+	return facetsGrpc
 }
 
 // Update implements ProductInterface.
@@ -189,39 +795,194 @@ func (i *ProductDB) UpdateImageUrl(product *models.Product) (*models.Product, er
 	return product, nil
 }
 
+// SavePrice implements ProductInterface.
+// Adds the price or replaces the product's existing price in the same currency.
+func (i *ProductDB) SavePrice(price *models.ProductPrice, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price_minor", "stripe_price_id"}),
+	}).Create(price).Error
+}
+
+// DeletePrice implements ProductInterface.
+func (i *ProductDB) DeletePrice(productId uint64, currency string, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Where("product_id = ?", productId).Where("currency = ?", currency).Delete(&models.ProductPrice{}).Error
+}
+
+// PublishScheduled implements ProductInterface.
+// Publishes the scheduled products that are due and returns how many there were.
+func (i *ProductDB) PublishScheduled(now time.Time) (int64, error) {
+	ret := i.write.Model(&models.Product{}).
+		Where("status = ? AND publish_at <= ?", models.ProductStatusScheduled, now).
+		Update("status", models.ProductStatusPublished)
+	return ret.RowsAffected, ret.Error
+}
+
+// preloadProduct loads the prices, options, live variants, categories and attributes along with the product
+func preloadProduct(db *gorm.DB) *gorm.DB {
+	return db.Preload("Prices").Preload("Categories").
+		Preload("Attributes", func(db *gorm.DB) *gorm.DB { return db.Order("key ASC") }).
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Where("is_deleted = false").Order("id ASC") })
+}
+
+// SaveSpecifications implements ProductInterface.
+// Replaces all specifications and custom attributes of the product.
+func (i *ProductDB) SaveSpecifications(productId uint64, specs models.ProductSpecifications, attributes []models.ProductAttribute, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.Product{}).Where("id = ?", productId).Updates(map[string]interface{}{
+		"brand":        specs.Brand,
+		"material":     specs.Material,
+		"weight_grams": specs.WeightGrams,
+		"length_mm":    specs.LengthMm,
+		"width_mm":     specs.WidthMm,
+		"height_mm":    specs.HeightMm,
+	})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return errors.New("no product found with the given ID")
+	}
+
+	if err := db.Where("product_id = ?", productId).Delete(&models.ProductAttribute{}).Error; err != nil {
+		return err
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	for i := range attributes {
+		attributes[i].ProductId = productId
+	}
+	return db.Create(&attributes).Error
+}
+
 func NewProductTable(read, write *gorm.DB) ProductInterface {
 	StorageInstance.AutoMigrate(&models.Product{})
-	return &ProductDB{
+	StorageInstance.AutoMigrate(&models.ProductPrice{})
+	StorageInstance.AutoMigrate(&models.ProductAttribute{})
+	StorageInstance.MigrateToMinorUnits(&models.Product{}, "price", "price_minor")
+	StorageInstance.MigrateCurrency(&models.Product{})
+	productDB := &ProductDB{
 		read:  read,
 		write: write,
 	}
+	productDB.migrateSearch()
+
+	// Products deleted before deletion times were kept start their retention now
+	if err := write.Model(&models.Product{}).Where("is_deleted = true AND deleted_at IS NULL").Update("deleted_at", time.Now()).Error; err != nil {
+		log.Printf("failed to backfill deleted_at of products: %v", err)
+	}
+	return productDB
 }
 
 func GrpcToDB(product *pb.Product) *models.Product {
+	// publish_at is validated by the service, an invalid one leaves it unset
+	var publishAt *time.Time
+	if t, err := time.Parse(time.RFC3339, product.PublishAt); err == nil {
+		publishAt = &t
+	}
 	return &models.Product{
 		Id:              product.Id,
 		Name:            product.Name,
-		Price:           product.Price,
+		PriceMinor:      product.PriceMinor,
+		Currency:        product.Currency,
 		Inventory:       product.Inventory,
 		Description:     product.Description,
 		Images:          product.Images,
 		StripePriceId:   product.StripePriceId,
 		StripeProductId: product.StripeProductId,
 		MerchantId:      product.MerchantId,
+		Status:          models.ProductStatus(product.Status),
+		PublishAt:       publishAt,
 	}
 }
 
 func DBToGrpc(product *models.Product) *pb.Product {
+	var publishAt, deletedAt string
+	if product.PublishAt != nil {
+		publishAt = product.PublishAt.Format(time.RFC3339)
+	}
+	if product.DeletedAt != nil {
+		deletedAt = product.DeletedAt.Format(time.RFC3339)
+	}
 	return &pb.Product{
 		Id:              product.Id,
 		Name:            product.Name,
-		Price:           product.Price,
+		Price:           models.FromMinorUnits(product.PriceMinor, product.Currency),
+		PriceMinor:      product.PriceMinor,
+		Currency:        product.Currency,
 		Inventory:       product.Inventory,
 		Description:     product.Description,
 		Images:          product.Images,
 		StripePriceId:   product.StripePriceId,
 		StripeProductId: product.StripeProductId,
 		MerchantId:      product.MerchantId,
+		Prices:          pricesDBToGrpc(product.Prices),
+		Options:         optionsDBToGrpc(product.Options),
+		Variants:        variantsDBToGrpc(product.Variants),
+		Categories:      categoriesDBToGrpc(product.Categories),
+		CreatedAt:       product.CreatedAt.Format(time.RFC3339),
+		Specifications:  specificationsDBToGrpc(product.Specifications, product.Attributes),
+		Status:          string(product.Status),
+		PublishAt:       publishAt,
+		IsDeleted:       product.IsDeleted,
+		DeletedAt:       deletedAt,
+	}
+}
+
+func pricesDBToGrpc(prices []models.ProductPrice) []*pb.ProductPrice {
+	pricesGrpc := make([]*pb.ProductPrice, 0, len(prices))
+	for _, price := range prices {
+		pricesGrpc = append(pricesGrpc, &pb.ProductPrice{
+			Currency:      price.Currency,
+			PriceMinor:    price.PriceMinor,
+			StripePriceId: price.StripePriceId,
+		})
+	}
+	return pricesGrpc
+}
+
+func specificationsDBToGrpc(specs models.ProductSpecifications, attributes []models.ProductAttribute) *pb.ProductSpecifications {
+	attributesGrpc := make([]*pb.ProductAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		attributeGrpc := &pb.ProductAttribute{
+			Key:  attribute.Key,
+			Type: string(attribute.Type),
+		}
+		switch attribute.Type {
+		case models.AttributeTypeNumber:
+			if attribute.NumberValue != nil {
+				attributeGrpc.NumberValue = *attribute.NumberValue
+			}
+		case models.AttributeTypeBoolean:
+			attributeGrpc.BoolValue = attribute.Value == "true"
+		default:
+			attributeGrpc.StringValue = attribute.Value
+		}
+		attributesGrpc = append(attributesGrpc, attributeGrpc)
+	}
+
+	return &pb.ProductSpecifications{
+		Brand:       specs.Brand,
+		Material:    specs.Material,
+		WeightGrams: specs.WeightGrams,
+		LengthMm:    specs.LengthMm,
+		WidthMm:     specs.WidthMm,
+		HeightMm:    specs.HeightMm,
+		Attributes:  attributesGrpc,
 	}
 }
 
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProductCursorRoundTrip(t *testing.T) {
+	cursor := productCursor{Sort: ProductSortPriceAsc, Currency: "SGD", Value: "1250", Id: 42}
+
+	decoded, err := decodeProductCursor(cursor.encode())
+	if err != nil {
+		t.Fatalf("decodeProductCursor: %v", err)
+	}
+	if decoded != cursor {
+		t.Errorf("decoded cursor %+v, want %+v", decoded, cursor)
+	}
+}
+
+func TestDecodeProductCursorRejectsTampering(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := decodeProductCursor(token); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("decodeProductCursor(%q) = %v, want ErrInvalidPageToken", token, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"product/models"
+	pb "product/proto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefundInterface interface {
+	CreateRefund(refund *models.Refund, tx *gorm.DB) (*models.Refund, error)
+	UpdateRefund(refund *models.Refund, tx *gorm.DB) error
+	GetUnsubmittedRefund(orderId uint64, tx *gorm.DB) (*models.Refund, error)
+}
+
+type RefundDB struct {
+	write *gorm.DB
+}
+
+func NewRefundTable(write *gorm.DB) RefundInterface {
+	StorageInstance.AutoMigrate(&models.Refund{})
+	StorageInstance.AutoMigrate(&models.RefundItem{})
+	StorageInstance.MigratePrimaryKey("refund_items", "refund_id", "product_id", "variant_id")
+	StorageInstance.MigrateToMinorUnits(&models.Refund{}, "amount", "amount_minor")
+	StorageInstance.MigrateToMinorUnits(&models.RefundItem{}, "amount", "amount_minor")
+	StorageInstance.MigrateCurrency(&models.Refund{})
+	return &RefundDB{
+		write: write,
+	}
+}
+
+// CreateRefund implements RefundInterface.
+func (i *RefundDB) CreateRefund(refund *models.Refund, tx *gorm.DB) (*models.Refund, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Create(refund)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return refund, nil
+}
+
+// UpdateRefund implements RefundInterface.
+func (i *RefundDB) UpdateRefund(refund *models.Refund, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.Refund{}).Where("id = ?", refund.Id).Updates(map[string]interface{}{
+		"stripe_refund_id": refund.StripeRefundId,
+		"status":           refund.Status,
+	})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	return nil
+}
+
+// GetUnsubmittedRefund implements RefundInterface.
+// Gets the oldest refund of the order that was saved but never accepted by Stripe, with its items.
+func (i *RefundDB) GetUnsubmittedRefund(orderId uint64, tx *gorm.DB) (*models.Refund, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	refund := &models.Refund{}
+	ret := db.Preload("Items").Where("order_id = ?", orderId).Where("status = ?", models.RefundStatusPending).
+		Where("COALESCE(stripe_refund_id, '') = ''").Order("id ASC").First(refund)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return refund, nil
+}
+
+func RefundDBToGrpc(refund *models.Refund) *pb.Refund {
+	items := make([]*pb.RefundItem, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		items = append(items, &pb.RefundItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return &pb.Refund{
+		Id:             refund.Id,
+		OrderId:        refund.OrderId,
+		StripeRefundId: refund.StripeRefundId,
+		Amount:         models.FromMinorUnits(refund.AmountMinor, refund.Currency),
+		AmountMinor:    refund.AmountMinor,
+		Currency:       refund.Currency,
+		Reason:         refund.Reason,
+		Status:         string(refund.Status),
+		Restocked:      refund.Restocked,
+		Items:          items,
+		CreatedAt:      refund.CreatedAt.Format(time.RFC3339),
+	}
+}
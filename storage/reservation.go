@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"product/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationInterface interface {
+	Reserved(productId, variantId uint64, now time.Time, tx *gorm.DB) (uint64, error)
+	Release(orderId uint64, tx *gorm.DB) ([]models.Reservation, error)
+	Extend(orderId uint64, expiresAt time.Time, tx *gorm.DB) error
+	ListExpiredOrderIds(now time.Time, limit int) ([]uint64, error)
+}
+
+type ReservationDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewReservationTable(read, write *gorm.DB) ReservationInterface {
+	StorageInstance.AutoMigrate(&models.Reservation{})
+	return &ReservationDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// Reserved implements ReservationInterface.
+// Sums the stock of the product, or of its variant, held by reservations that have not expired.
+// Lock the product or variant row first so the sum can not change before stock is reserved.
+func (i *ReservationDB) Reserved(productId, variantId uint64, now time.Time, tx *gorm.DB) (uint64, error) {
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+
+	var reserved uint64
+	ret := db.Model(&models.Reservation{}).Select("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ? AND variant_id = ?", productId, variantId).
+		Where("expires_at > ?", now).Scan(&reserved)
+	if ret.Error != nil {
+		return 0, ret.Error
+	}
+	return reserved, nil
+}
+
+// Release implements ReservationInterface.
+// Deletes the reservations of the order and returns them, expired ones included.
+func (i *ReservationDB) Release(orderId uint64, tx *gorm.DB) ([]models.Reservation, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	var reservations []models.Reservation
+	ret := db.Clauses(clause.Returning{}).Where("order_id = ?", orderId).Delete(&reservations)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return reservations, nil
+}
+
+// Extend implements ReservationInterface.
+// Moves the expiry of every reservation of the order.
+func (i *ReservationDB) Extend(orderId uint64, expiresAt time.Time, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Model(&models.Reservation{}).Where("order_id = ?", orderId).Update("expires_at", expiresAt).Error
+}
+
+// ListExpiredOrderIds implements ReservationInterface.
+// Lists the orders holding expired reservations, the ones that expired first come first.
+// Orders waiting for a delayed payment are left out, the payment webhooks settle them.
+func (i *ReservationDB) ListExpiredOrderIds(now time.Time, limit int) ([]uint64, error) {
+	var orderIds []uint64
+	ret := i.write.Model(&models.Reservation{}).Select("reservations.order_id").
+		Joins("JOIN orders ON orders.id = reservations.order_id").
+		Where("reservations.expires_at <= ?", now).
+		Where("orders.payment_status <> ?", models.PaymentStatusProcessing).
+		Group("reservations.order_id").Order("MIN(reservations.expires_at) ASC").Limit(limit).Scan(&orderIds)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return orderIds, nil
+}
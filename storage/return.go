@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"product/models"
+	pb "product/proto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReturnInterface interface {
+	CreateReturn(ret *models.Return, tx *gorm.DB) (*models.Return, error)
+	UpdateReturn(ret *models.Return, tx *gorm.DB) error
+	CreateStatusChange(change *models.ReturnStatusChange, tx *gorm.DB) error
+	GetReturn(id uint64, tx *gorm.DB) (*models.Return, error)
+	ListByOrderId(orderId uint64) ([]*models.Return, error)
+}
+
+type ReturnDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewReturnTable(read, write *gorm.DB) ReturnInterface {
+	StorageInstance.AutoMigrate(&models.Return{})
+	StorageInstance.AutoMigrate(&models.ReturnItem{})
+	StorageInstance.MigratePrimaryKey("return_items", "return_id", "product_id", "variant_id")
+	StorageInstance.AutoMigrate(&models.ReturnStatusChange{})
+	return &ReturnDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// CreateReturn implements ReturnInterface.
+func (i *ReturnDB) CreateReturn(ret *models.Return, tx *gorm.DB) (*models.Return, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	if err := db.Create(ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// UpdateReturn implements ReturnInterface.
+// Items of a return can not change once it has been requested.
+func (i *ReturnDB) UpdateReturn(ret *models.Return, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	return db.Model(&models.Return{}).Where("id = ?", ret.Id).Updates(map[string]interface{}{
+		"status":    ret.Status,
+		"restocked": ret.Restocked,
+		"refund_id": ret.RefundId,
+	}).Error
+}
+
+// CreateStatusChange implements ReturnInterface.
+func (i *ReturnDB) CreateStatusChange(change *models.ReturnStatusChange, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Create(change).Error
+}
+
+// GetReturn implements ReturnInterface.
+func (i *ReturnDB) GetReturn(id uint64, tx *gorm.DB) (*models.Return, error) {
+	ret := &models.Return{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	err := db.Preload("Items").Preload("History", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("id = ?", id).First(ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ListByOrderId implements ReturnInterface.
+func (i *ReturnDB) ListByOrderId(orderId uint64) ([]*models.Return, error) {
+	var returns []*models.Return
+	err := i.read.Preload("Items").Preload("History", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("order_id = ?", orderId).Order("id ASC").Find(&returns).Error
+	if err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+func ReturnDBToGrpc(ret *models.Return) *pb.Return {
+	items := make([]*pb.ReturnItem, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		items = append(items, &pb.ReturnItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+		})
+	}
+	history := make([]*pb.ReturnStatusChange, 0, len(ret.History))
+	for _, change := range ret.History {
+		history = append(history, &pb.ReturnStatusChange{
+			Status:    string(change.Status),
+			Note:      change.Note,
+			ActorId:   change.ActorId,
+			CreatedAt: change.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	var refundId uint64
+	if ret.RefundId != nil {
+		refundId = *ret.RefundId
+	}
+
+	return &pb.Return{
+		Id:         ret.Id,
+		OrderId:    ret.OrderId,
+		SubOrderId: ret.SubOrderId,
+		UserId:     ret.UserId,
+		MerchantId: ret.MerchantId,
+		Reason:     ret.Reason,
+		Status:     string(ret.Status),
+		Restocked:  ret.Restocked,
+		RefundId:   refundId,
+		Items:      items,
+		History:    history,
+		CreatedAt:  ret.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  ret.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func ReturnDBsToGrpcs(returns []*models.Return) []*pb.Return {
+	var returnsGrpc []*pb.Return
+	for _, ret := range returns {
+		returnsGrpc = append(returnsGrpc, ReturnDBToGrpc(ret))
+	}
+	return returnsGrpc
+}
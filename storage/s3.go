@@ -181,5 +181,5 @@ func (s *Minio) Put(filename string, file *bytes.Buffer) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("http://localhost:9000/%s/%s",s.bucket, unique_filename), nil
+	return fmt.Sprintf("http://localhost:9000/%s/%s", s.bucket, unique_filename), nil
 }
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"product/models"
+	pb "product/proto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShipmentInterface interface {
+	CreateShipment(shipment *models.Shipment, tx *gorm.DB) (*models.Shipment, error)
+	UpdateShipment(shipment *models.Shipment, tx *gorm.DB) error
+	GetShipment(id uint64, tx *gorm.DB) (*models.Shipment, error)
+}
+
+type ShipmentDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewShipmentTable(read, write *gorm.DB) ShipmentInterface {
+	StorageInstance.AutoMigrate(&models.Shipment{})
+	StorageInstance.AutoMigrate(&models.ShipmentItem{})
+	StorageInstance.MigratePrimaryKey("shipment_items", "shipment_id", "product_id", "variant_id")
+	return &ShipmentDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// CreateShipment implements ShipmentInterface.
+func (i *ShipmentDB) CreateShipment(shipment *models.Shipment, tx *gorm.DB) (*models.Shipment, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Create(shipment)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return shipment, nil
+}
+
+// UpdateShipment implements ShipmentInterface.
+// The items of a shipment can not change once it has been created.
+func (i *ShipmentDB) UpdateShipment(shipment *models.Shipment, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.Shipment{}).Where("id = ?", shipment.Id).Updates(map[string]interface{}{
+		"carrier":         shipment.Carrier,
+		"tracking_number": shipment.TrackingNumber,
+		"status":          shipment.Status,
+		"delivered_at":    shipment.DeliveredAt,
+	})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	return nil
+}
+
+// GetShipment implements ShipmentInterface.
+func (i *ShipmentDB) GetShipment(id uint64, tx *gorm.DB) (*models.Shipment, error) {
+	shipment := &models.Shipment{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Preload("Items").Where("id = ?", id).First(shipment)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return shipment, nil
+}
+
+func ShipmentDBToGrpc(shipment *models.Shipment) *pb.Shipment {
+	items := make([]*pb.ShipmentItem, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		items = append(items, &pb.ShipmentItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	shipmentGrpc := &pb.Shipment{
+		Id:             shipment.Id,
+		OrderId:        shipment.OrderId,
+		MerchantId:     shipment.MerchantId,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Status:         string(shipment.Status),
+		Items:          items,
+		CreatedAt:      shipment.CreatedAt.Format(time.RFC3339),
+	}
+	if shipment.ShippedAt != nil {
+		shipmentGrpc.ShippedAt = shipment.ShippedAt.Format(time.RFC3339)
+	}
+	if shipment.DeliveredAt != nil {
+		shipmentGrpc.DeliveredAt = shipment.DeliveredAt.Format(time.RFC3339)
+	}
+	return shipmentGrpc
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"product/models"
+	pb "product/proto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockLedgerInterface is append-only, stock movements are never updated or deleted
+type StockLedgerInterface interface {
+	CreateMovements(movements []*models.StockMovement, tx *gorm.DB) error
+	ListByProductId(productId uint64, filter StockMovementFilter, limit uint64, cursorID uint64) ([]*models.StockMovement, uint64, error)
+}
+
+// StockMovementFilter narrows down the stock history of a product, zero values match everything
+type StockMovementFilter struct {
+	VariantId uint64
+	Type      models.StockMovementType
+}
+
+func (f StockMovementFilter) apply(db *gorm.DB) *gorm.DB {
+	if f.VariantId != 0 {
+		db = db.Where("variant_id = ?", f.VariantId)
+	}
+	if f.Type != "" {
+		db = db.Where("type = ?", f.Type)
+	}
+	return db
+}
+
+type StockLedgerDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewStockLedgerTable(read, write *gorm.DB) StockLedgerInterface {
+	StorageInstance.AutoMigrate(&models.StockMovement{})
+	return &StockLedgerDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// CreateMovements implements StockLedgerInterface.
+func (i *StockLedgerDB) CreateMovements(movements []*models.StockMovement, tx *gorm.DB) error {
+	if len(movements) == 0 {
+		return nil
+	}
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Create(movements).Error
+}
+
+// ListByProductId implements StockLedgerInterface.
+// Lists the movements of the product and its variants, oldest first, after the cursor.
+// Returns the ID of the last movement as the next cursor.
+func (i *StockLedgerDB) ListByProductId(productId uint64, filter StockMovementFilter, limit uint64, cursorID uint64) ([]*models.StockMovement, uint64, error) {
+	var movements []*models.StockMovement
+
+	query := filter.apply(i.read.Where("product_id = ?", productId).Order("id ASC"))
+	if cursorID > 0 {
+		query = query.Where("id > ?", cursorID)
+	}
+	if limit > 0 {
+		query = query.Limit(int(limit))
+	}
+	if err := query.Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var nextCursor uint64
+	if len(movements) > 0 {
+		nextCursor = movements[len(movements)-1].Id
+	}
+	return movements, nextCursor, nil
+}
+
+func StockMovementsDBToGrpc(movements []*models.StockMovement) []*pb.StockMovement {
+	var movementsGrpc []*pb.StockMovement
+	for _, movement := range movements {
+		movementsGrpc = append(movementsGrpc, &pb.StockMovement{
+			Id:          movement.Id,
+			ProductId:   movement.ProductId,
+			VariantId:   movement.VariantId,
+			Type:        string(movement.Type),
+			Delta:       movement.Delta,
+			Quantity:    movement.Quantity,
+			Actor:       movement.Actor,
+			ReferenceId: movement.ReferenceId,
+			CreatedAt:   movement.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return movementsGrpc
+}
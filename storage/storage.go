@@ -5,20 +5,35 @@ import (
 	"log"
 	"os"
 	"product/configs"
+	"product/models"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
 type Storage struct {
-	read    *gorm.DB
-	write   *gorm.DB
-	Product ProductInterface
-	S3      S3Interface
-	Order   OrderInterface
+	read        *gorm.DB
+	write       *gorm.DB
+	Product     ProductInterface
+	S3          S3Interface
+	Order       OrderInterface
+	Stripe      StripeEventInterface
+	Refund      RefundInterface
+	Merchant    MerchantInterface
+	Ledger      LedgerInterface
+	Shipment    ShipmentInterface
+	Return      ReturnInterface
+	Variant     VariantInterface
+	Category    CategoryInterface
+	Reservation ReservationInterface
+	StockLedger StockLedgerInterface
 }
 
 func (s *Storage) InitDB() {
@@ -56,6 +71,12 @@ func (s *Storage) InitDB() {
 	readDB.SetMaxIdleConns(configs.POSTGRESQL_MAX_IDLE_CONNS)
 }
 
+// UseDB makes the storage use connections opened elsewhere instead of the ones InitDB opens, e.g. in tests
+func (s *Storage) UseDB(read, write *gorm.DB) {
+	s.read = read
+	s.write = write
+}
+
 func (s *Storage) GetWriteDB() *gorm.DB {
 	return s.write
 }
@@ -69,6 +90,130 @@ func (s *Storage) AutoMigrate(model interface{}) {
 	s.read.AutoMigrate(model)
 }
 
+// CreateEnum creates the postgres enum type if it does not exist yet,
+// and adds any values that are missing from an existing type.
+func (s *Storage) CreateEnum(name string, values ...string) {
+	for _, db := range []*gorm.DB{s.write, s.read} {
+		create := fmt.Sprintf(`DO $$ BEGIN CREATE TYPE %s AS ENUM (); EXCEPTION WHEN duplicate_object THEN NULL; END $$;`, pq.QuoteIdentifier(name))
+		if err := db.Exec(create).Error; err != nil {
+			log.Printf("failed to create enum %s: %v", name, err)
+			continue
+		}
+		for _, value := range values {
+			alter := fmt.Sprintf(`ALTER TYPE %s ADD VALUE IF NOT EXISTS %s`, pq.QuoteIdentifier(name), pq.QuoteLiteral(value))
+			if err := db.Exec(alter).Error; err != nil {
+				log.Printf("failed to add value %s to enum %s: %v", value, name, err)
+			}
+		}
+	}
+}
+
+// RunOnce runs a data migration on the write database unless it ran before.
+// The migration is recorded in the same transaction, instances starting together wait for
+// the one that records it first and then skip it.
+func (s *Storage) RunOnce(name string, migrate func(tx *gorm.DB) error) {
+	tx := s.write.Begin()
+	ret := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Migration{Name: name})
+	if ret.Error != nil {
+		tx.Rollback()
+		log.Printf("failed to record migration %s: %v", name, ret.Error)
+		return
+	}
+	if ret.RowsAffected == 0 {
+		tx.Rollback()
+		return
+	}
+	if err := migrate(tx); err != nil {
+		tx.Rollback()
+		log.Printf("failed to run migration %s: %v", name, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.Printf("failed to commit migration %s: %v", name, err)
+	}
+}
+
+// MigrateToMinorUnits backfills an integer minor unit column from the float column it replaced, once.
+// Legacy amounts were all in SGD, so they are converted at 100 cents to the dollar.
+// Only rows that predate the minor unit column are touched, amounts written since are never overwritten.
+// The float column is no longer written and goes stale, nothing may read it anymore.
+func (s *Storage) MigrateToMinorUnits(model interface{}, floatColumn, minorColumn string) {
+	if !s.write.Migrator().HasColumn(model, floatColumn) {
+		return
+	}
+	table, err := s.tableName(model)
+	if err != nil {
+		log.Printf("failed to migrate %s to %s: %v", floatColumn, minorColumn, err)
+		return
+	}
+	s.RunOnce(fmt.Sprintf("minor_units_%s_%s", table, minorColumn), func(tx *gorm.DB) error {
+		return tx.Model(model).
+			Where(fmt.Sprintf("%s IS NULL", pq.QuoteIdentifier(minorColumn))).
+			Update(minorColumn, gorm.Expr(fmt.Sprintf("ROUND(%s::numeric * 100)", pq.QuoteIdentifier(floatColumn)))).Error
+	})
+}
+
+// MigrateCurrency drops the SGD default the currency column of the model used to have, once.
+// Rows without a currency get the configured default currency, which new rows get from the services creating them.
+func (s *Storage) MigrateCurrency(model interface{}) {
+	table, err := s.tableName(model)
+	if err != nil {
+		log.Printf("failed to migrate currency: %v", err)
+		return
+	}
+	s.RunOnce(fmt.Sprintf("currency_default_%s", table), func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN currency DROP DEFAULT", pq.QuoteIdentifier(table))).Error; err != nil {
+			return err
+		}
+		return tx.Model(model).Where("currency IS NULL OR currency = ''").Update("currency", configs.DEFAULT_CURRENCY).Error
+	})
+}
+
+// tableName is the table gorm stores the model in
+func (s *Storage) tableName(model interface{}) (string, error) {
+	stmt := &gorm.Statement{DB: s.write}
+	if err := stmt.Parse(model); err != nil {
+		return "", err
+	}
+	return stmt.Schema.Table, nil
+}
+
+// MigratePrimaryKey replaces the primary key of an existing table with one over the given columns.
+// AutoMigrate adds new key columns to existing tables but leaves their primary key as it was.
+func (s *Storage) MigratePrimaryKey(table string, columns ...string) {
+	want := slices.Sorted(slices.Values(columns))
+	for _, db := range []*gorm.DB{s.write, s.read} {
+		var current []string
+		if err := db.Raw(`SELECT a.attname FROM pg_index i
+			JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+			WHERE i.indrelid = ?::regclass AND i.indisprimary`, table).Scan(&current).Error; err != nil {
+			log.Printf("failed to read primary key of %s: %v", table, err)
+			continue
+		}
+		slices.Sort(current)
+		if slices.Equal(current, want) {
+			continue
+		}
+
+		var constraint string
+		if err := db.Raw(`SELECT conname FROM pg_constraint WHERE conrelid = ?::regclass AND contype = 'p'`, table).Scan(&constraint).Error; err != nil {
+			log.Printf("failed to read primary key of %s: %v", table, err)
+			continue
+		}
+		quoted := make([]string, 0, len(columns))
+		for _, column := range columns {
+			quoted = append(quoted, pq.QuoteIdentifier(column))
+		}
+		alter := fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", pq.QuoteIdentifier(table), strings.Join(quoted, ", "))
+		if constraint != "" {
+			alter = fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s, ADD PRIMARY KEY (%s)", pq.QuoteIdentifier(table), pq.QuoteIdentifier(constraint), strings.Join(quoted, ", "))
+		}
+		if err := db.Exec(alter).Error; err != nil {
+			log.Printf("failed to migrate primary key of %s: %v", table, err)
+		}
+	}
+}
+
 func (s *Storage) BeginTransaction() *gorm.DB {
 	return s.write.Begin()
 }
@@ -80,13 +225,24 @@ func GetStorageInstance() *Storage {
 	once.Do(func() {
 		StorageInstance = &Storage{}
 		StorageInstance.InitDB()
+		StorageInstance.write.AutoMigrate(&models.Migration{})
+		StorageInstance.Category = NewCategoryTable(StorageInstance.read, StorageInstance.write)
 		StorageInstance.Product = NewProductTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.Variant = NewVariantTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.StockLedger = NewStockLedgerTable(StorageInstance.read, StorageInstance.write)
 		if configs.ENVIRONMENT == "prod" {
 			StorageInstance.S3 = NewS3()
 		} else {
 			StorageInstance.S3 = NewMinio()
 		}
-		StorageInstance.Order = NewOrderTable(StorageInstance.write)
+		StorageInstance.Order = NewOrderTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.Reservation = NewReservationTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.Stripe = NewStripeEventTable(StorageInstance.write)
+		StorageInstance.Refund = NewRefundTable(StorageInstance.write)
+		StorageInstance.Merchant = NewMerchantTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.Ledger = NewLedgerTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.Shipment = NewShipmentTable(StorageInstance.read, StorageInstance.write)
+		StorageInstance.Return = NewReturnTable(StorageInstance.read, StorageInstance.write)
 	})
 	return StorageInstance
 }
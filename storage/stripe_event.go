@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"product/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StripeEventInterface interface {
+	CreateEvent(event *models.StripeEvent, tx *gorm.DB) (bool, error)
+}
+
+type StripeEventDB struct {
+	write *gorm.DB
+}
+
+func NewStripeEventTable(write *gorm.DB) StripeEventInterface {
+	StorageInstance.AutoMigrate(&models.StripeEvent{})
+	return &StripeEventDB{
+		write: write,
+	}
+}
+
+// CreateEvent implements StripeEventInterface.
+// Returns false if the event has already been recorded, i.e. it is a replay.
+func (i *StripeEventDB) CreateEvent(event *models.StripeEvent, tx *gorm.DB) (bool, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if ret.Error != nil {
+		return false, ret.Error
+	}
+	return ret.RowsAffected > 0, nil
+}
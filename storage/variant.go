@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"errors"
+	"product/models"
+	pb "product/proto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VariantInterface interface {
+	CreateVariant(variant *models.ProductVariant, tx *gorm.DB) (*models.ProductVariant, error)
+	UpdateVariant(variant *models.ProductVariant, tx *gorm.DB) error
+	GetVariant(id uint64, tx *gorm.DB) (*models.ProductVariant, error)
+	GetVariantWithLock(id uint64, tx *gorm.DB) (*models.ProductVariant, error)
+	GetVariantBySku(sku string, tx *gorm.DB) (*models.ProductVariant, error)
+	UpdateVariantInventory(id, inventory uint64, tx *gorm.DB) error
+	SetVariantStockWithLock(id, inventory uint64, tx *gorm.DB) (uint64, error)
+	RestockVariantWithLock(id, quantity uint64, tx *gorm.DB) (uint64, error)
+	TakeVariantStockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, uint64, error)
+	UpdateVariantImages(id uint64, images []string) error
+	DeleteVariant(id uint64, tx *gorm.DB) error
+	SaveOptions(productId uint64, options []models.ProductOption, tx *gorm.DB) error
+}
+
+type VariantDB struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func NewVariantTable(read, write *gorm.DB) VariantInterface {
+	StorageInstance.AutoMigrate(&models.ProductOption{})
+	StorageInstance.AutoMigrate(&models.ProductVariant{})
+	return &VariantDB{
+		read:  read,
+		write: write,
+	}
+}
+
+// CreateVariant implements VariantInterface.
+func (i *VariantDB) CreateVariant(variant *models.ProductVariant, tx *gorm.DB) (*models.ProductVariant, error) {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Create(variant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return variant, nil
+}
+
+// UpdateVariant implements VariantInterface.
+// All fields are written, so the price and inventory can be set back to 0.
+func (i *VariantDB) UpdateVariant(variant *models.ProductVariant, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+
+	ret := db.Model(&models.ProductVariant{}).Where("id = ?", variant.Id).Where("is_deleted = false").Updates(map[string]interface{}{
+		"sku":             variant.Sku,
+		"option_values":   variant.OptionValues,
+		"price_minor":     variant.PriceMinor,
+		"inventory":       variant.Inventory,
+		"stripe_price_id": variant.StripePriceId,
+	})
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return errors.New("no variant found with the given ID")
+	}
+	return nil
+}
+
+// GetVariant implements VariantInterface.
+func (i *VariantDB) GetVariant(id uint64, tx *gorm.DB) (*models.ProductVariant, error) {
+	variant := &models.ProductVariant{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("id = ?", id).Where("is_deleted = false").First(variant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return variant, nil
+}
+
+// GetVariantBySku implements VariantInterface.
+// Deleted variants keep their SKU, so they are found as well.
+func (i *VariantDB) GetVariantBySku(sku string, tx *gorm.DB) (*models.ProductVariant, error) {
+	variant := &models.ProductVariant{}
+	db := tx
+	if db == nil {
+		db = i.read
+	}
+	ret := db.Where("sku = ?", sku).First(variant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return variant, nil
+}
+
+// GetVariantWithLock implements VariantInterface.
+// Preparation to update the variant's inventory.
+func (i *VariantDB) GetVariantWithLock(id uint64, tx *gorm.DB) (*models.ProductVariant, error) {
+	variant := &models.ProductVariant{}
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	ret := tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Where("id = ?", id).Where("is_deleted = false").First(variant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return variant, nil
+}
+
+// UpdateVariantInventory implements VariantInterface.
+func (i *VariantDB) UpdateVariantInventory(id, inventory uint64, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	ret := tx.Model(&models.ProductVariant{}).Where("id = ?", id).Update("inventory", inventory)
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return errors.New("no variant found with the given ID")
+	}
+	return nil
+}
+
+// SetVariantStockWithLock implements VariantInterface.
+// Locks the product row and then the variant's, sets the variant's inventory and returns the previous one.
+func (i *VariantDB) SetVariantStockWithLock(id, inventory uint64, tx *gorm.DB) (uint64, error) {
+	variant, err := i.lockVariant(id, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return variant.Inventory, i.UpdateVariantInventory(id, inventory, tx)
+}
+
+// RestockVariantWithLock implements VariantInterface.
+// The product row is locked before the variant, like when an order is placed.
+// Deleted variants are restocked as well, like deleted products. Returns the new inventory.
+func (i *VariantDB) RestockVariantWithLock(id, quantity uint64, tx *gorm.DB) (uint64, error) {
+	variant, err := i.lockVariant(id, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	inventory := variant.Inventory + quantity
+	return inventory, i.UpdateVariantInventory(id, inventory, tx)
+}
+
+// TakeVariantStockWithLock implements VariantInterface.
+// Like RestockVariantWithLock it locks the product row before the variant.
+// Takes the quantity out of the variant's inventory without going below zero,
+// returns the new inventory and how much was missing.
+func (i *VariantDB) TakeVariantStockWithLock(id, quantity uint64, tx *gorm.DB) (uint64, uint64, error) {
+	variant, err := i.lockVariant(id, tx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if variant.Inventory < quantity {
+		return 0, quantity - variant.Inventory, i.UpdateVariantInventory(id, 0, tx)
+	}
+	inventory := variant.Inventory - quantity
+	return inventory, 0, i.UpdateVariantInventory(id, inventory, tx)
+}
+
+// lockVariant locks the row of the variant's product and then the variant's own, deleted ones included
+func (i *VariantDB) lockVariant(id uint64, tx *gorm.DB) (*models.ProductVariant, error) {
+	variant := &models.ProductVariant{}
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	ret := tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Where("id = (?)", tx.Model(&models.ProductVariant{}).Select("product_id").Where("id = ?", id)).First(&models.Product{})
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	ret = tx.Clauses(clause.Locking{
+		Strength: "UPDATE",
+	}).Where("id = ?", id).First(variant)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	return variant, nil
+}
+
+// UpdateVariantImages implements VariantInterface.
+func (i *VariantDB) UpdateVariantImages(id uint64, images []string) error {
+	ret := i.write.Model(&models.ProductVariant{}).Where("id = ?", id).Where("is_deleted = false").Update("images", images)
+	if ret.Error != nil {
+		return ret.Error
+	}
+	if ret.RowsAffected == 0 {
+		return errors.New("no variant found with the given ID")
+	}
+	return nil
+}
+
+// DeleteVariant implements VariantInterface.
+// Variants are only marked as deleted because order items keep referring to them.
+func (i *VariantDB) DeleteVariant(id uint64, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	return db.Model(&models.ProductVariant{}).Where("id = ?", id).Update("is_deleted", true).Error
+}
+
+// SaveOptions implements VariantInterface.
+// Replaces all options of the product.
+func (i *VariantDB) SaveOptions(productId uint64, options []models.ProductOption, tx *gorm.DB) error {
+	db := tx
+	if db == nil {
+		db = i.write
+	}
+	if err := db.Where("product_id = ?", productId).Delete(&models.ProductOption{}).Error; err != nil {
+		return err
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return db.Create(&options).Error
+}
+
+func VariantDBToGrpc(variant *models.ProductVariant) *pb.ProductVariant {
+	return &pb.ProductVariant{
+		Id:            variant.Id,
+		ProductId:     variant.ProductId,
+		Sku:           variant.Sku,
+		OptionValues:  variant.OptionValues,
+		PriceMinor:    variant.PriceMinor,
+		Inventory:     variant.Inventory,
+		Images:        variant.Images,
+		StripePriceId: variant.StripePriceId,
+	}
+}
+
+func optionsDBToGrpc(options []models.ProductOption) []*pb.ProductOption {
+	optionsGrpc := make([]*pb.ProductOption, 0, len(options))
+	for _, option := range options {
+		optionsGrpc = append(optionsGrpc, &pb.ProductOption{
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+	return optionsGrpc
+}
+
+func variantsDBToGrpc(variants []models.ProductVariant) []*pb.ProductVariant {
+	variantsGrpc := make([]*pb.ProductVariant, 0, len(variants))
+	for i := range variants {
+		variantsGrpc = append(variantsGrpc, VariantDBToGrpc(&variants[i]))
+	}
+	return variantsGrpc
+}